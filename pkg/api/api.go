@@ -7,12 +7,27 @@ import (
 	"time"
 )
 
-// Page is a response struct from the discord.bots.gg API.
+// Page is a response struct from the discord.bots.gg API. When requested from
+// the client, the raw response is retained in Raw and the fields of the
+// response which are not modelled in Extra.
 type Page struct {
 	Count int    `json:"count"`
 	Limit int    `json:"limit"`
 	Page  int    `json:"page"`
 	Bots  []*Bot `json:"bots"`
+
+	Raw   json.RawMessage            `json:"-"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, including the fields
+// retained in Extra.
+func (page Page) MarshalJSON() ([]byte, error) {
+	type pageAlias Page
+
+	object := pageAlias(page)
+
+	return encodeWithExtra(&object, page.Extra)
 }
 
 func (page *Page) String() string {
@@ -25,23 +40,26 @@ func (page *Page) String() string {
 	return strings.Join(botNames, ", ")
 }
 
-// Bot is a response struct from the discord.bots.gg API.
+// Bot is a response struct from the discord.bots.gg API. Fields which the API
+// may return as null are a NullString. When requested from the client, the raw
+// response is retained in Raw and the fields of the response which are not
+// modelled in Extra.
 type Bot struct {
 	UserID           string      `json:"userId"`
 	ClientID         string      `json:"clientId"`
 	Username         string      `json:"username"`
-	Discriminator    string      `json:"discriminator"`
-	AvatarURL        string      `json:"avatarURL"`
+	Discriminator    NullString  `json:"discriminator"`
+	AvatarURL        NullString  `json:"avatarURL"`
 	CoOwners         []*BotOwner `json:"coOwners"`
 	Prefix           string      `json:"prefix"`
 	HelpCommand      string      `json:"helpCommand"`
 	LibraryName      string      `json:"libraryName"`
-	Website          string      `json:"website"`
-	SupportInvite    string      `json:"supportInvite"`
-	BotInvite        string      `json:"botInvite"`
-	ShortDescription string      `json:"shortDescription"`
-	LongDescription  string      `json:"longDescription"`
-	OpenSource       string      `json:"openSource"`
+	Website          NullString  `json:"website"`
+	SupportInvite    NullString  `json:"supportInvite"`
+	BotInvite        NullString  `json:"botInvite"`
+	ShortDescription NullString  `json:"shortDescription"`
+	LongDescription  NullString  `json:"longDescription"`
+	OpenSource       NullString  `json:"openSource"`
 	ShardCount       int         `json:"shardCount"`
 	GuildCount       int         `json:"guildCount"`
 	Verified         bool        `json:"verified"`
@@ -50,6 +68,19 @@ type Bot struct {
 	Owner            *BotOwner   `json:"owner"`
 	AddedDate        time.Time   `json:"addedDate"`
 	Status           string      `json:"status"`
+
+	Raw   json.RawMessage            `json:"-"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, including the fields
+// retained in Extra.
+func (bot Bot) MarshalJSON() ([]byte, error) {
+	type botAlias Bot
+
+	object := botAlias(bot)
+
+	return encodeWithExtra(&object, bot.Extra)
 }
 
 // String satisfies the fmt.Stringer interface and returns the bot's name.
@@ -59,9 +90,9 @@ func (bot *Bot) String() string {
 
 // BotOwner is a response struct from the discord.bots.gg API.
 type BotOwner struct {
-	Username      string `json:"username"`
-	Discriminator string `json:"discriminator"`
-	UserID        string `json:"userId"`
+	Username      string     `json:"username"`
+	Discriminator NullString `json:"discriminator"`
+	UserID        string     `json:"userId"`
 }
 
 // StatsUpdate is a request struct for the discord.bots.gg API.
@@ -70,9 +101,24 @@ type StatsUpdate struct {
 	ShardID int `json:"shardID,omitempty"`
 }
 
-// StatsResponse is a response struct from the discord.bots.gg API.
+// StatsResponse is a response struct from the discord.bots.gg API. When
+// requested from the client, the raw response is retained in Raw and the
+// fields of the response which are not modelled in Extra.
 type StatsResponse struct {
 	*Stats
+
+	Raw   json.RawMessage            `json:"-"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, including the fields
+// retained in Extra.
+func (statsResponse StatsResponse) MarshalJSON() ([]byte, error) {
+	type statsResponseAlias StatsResponse
+
+	object := statsResponseAlias(statsResponse)
+
+	return encodeWithExtra(&object, statsResponse.Extra)
 }
 
 // String satisfies the fmt.Stringer interface and returns the JSON
@@ -1,14 +1,58 @@
 package api
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBaseURL is the base URL of the discord.bots.gg API.
+const DefaultBaseURL = "https://discord.bots.gg"
 
 const (
-	baseURL       = "https://discord.bots.gg"
-	botEndpoint   = baseURL + "/api/v1/bots/%s?sanitize=%t"
-	botsEndpoint  = baseURL + "/api/v1/bots"
-	statsEndpoint = baseURL + "/api/v1/bots/%s/stats"
+	botPath   = "/api/v1/bots/%s?sanitize=%t"
+	botsPath  = "/api/v1/bots"
+	statsPath = "/api/v1/bots/%s/stats"
+
+	botEndpoint   = DefaultBaseURL + botPath
+	botsEndpoint  = DefaultBaseURL + botsPath
+	statsEndpoint = DefaultBaseURL + statsPath
 )
 
+// Endpoints builds API URL strings relative to a base URL, such as a staging
+// server, a local stand-in or a proxy path.
+type Endpoints struct {
+	BaseURL string
+}
+
+// NewEndpoints returns a new *Endpoints for the given baseURL. An empty
+// baseURL uses DefaultBaseURL.
+func NewEndpoints(baseURL string) *Endpoints {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Endpoints{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Bot returns an API URL string for querying the given botID.
+func (endpoints *Endpoints) Bot(botID string, sanitize bool) string {
+	return fmt.Sprintf(endpoints.BaseURL+botPath, botID, sanitize)
+}
+
+// Bots returns an API URL string for querying bots.
+func (endpoints *Endpoints) Bots(queryParameters fmt.Stringer) string {
+	if queryParameters == nil {
+		return endpoints.BaseURL + botsPath
+	}
+
+	return fmt.Sprintf("%s?%s", endpoints.BaseURL+botsPath, queryParameters)
+}
+
+// Stats returns an API URL string for updating stats for the given botID.
+func (endpoints *Endpoints) Stats(botID string) string {
+	return fmt.Sprintf(endpoints.BaseURL+statsPath, botID)
+}
+
 // BotEndpoint returns an API URL string for querying the given botID.
 func BotEndpoint(botID string, sanitize bool) string {
 	return fmt.Sprintf(botEndpoint, botID, sanitize)
@@ -6,8 +6,9 @@ import (
 )
 
 const (
-	testBotID = "testBotID"
-	testQuery = "testQuery"
+	testBaseURL = "http://localhost:8080/proxy"
+	testBotID   = "testBotID"
+	testQuery   = "testQuery"
 )
 
 func TestBotEndpoint(t *testing.T) {
@@ -54,3 +55,64 @@ func TestStatsEndpoint(t *testing.T) {
 		t.Errorf("Unexpected result. Got: %s. Expected: %s", got, expected)
 	}
 }
+
+func TestNewEndpoints(t *testing.T) {
+	endpoints := NewEndpoints("")
+
+	if endpoints.BaseURL != DefaultBaseURL {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", endpoints.BaseURL, DefaultBaseURL)
+	}
+
+	endpoints = NewEndpoints(testBaseURL + "/")
+
+	if endpoints.BaseURL != testBaseURL {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", endpoints.BaseURL, testBaseURL)
+	}
+}
+
+func TestEndpoints_Bot(t *testing.T) {
+	got := NewEndpoints(testBaseURL).Bot(testBotID, true)
+	expected := fmt.Sprintf(testBaseURL+botPath, testBotID, true)
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", got, expected)
+	}
+
+	got = NewEndpoints("").Bot(testBotID, true)
+	expected = BotEndpoint(testBotID, true)
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", got, expected)
+	}
+}
+
+func TestEndpoints_Bots(t *testing.T) {
+	endpoints := NewEndpoints(testBaseURL)
+
+	got := endpoints.Bots(nil)
+	expected := testBaseURL + botsPath
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", got, expected)
+	}
+
+	queryParameters := &QueryParameters{
+		Q: testQuery,
+	}
+
+	got = endpoints.Bots(queryParameters)
+	expected = fmt.Sprintf("%s?%s", testBaseURL+botsPath, queryParameters)
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", got, expected)
+	}
+}
+
+func TestEndpoints_Stats(t *testing.T) {
+	got := NewEndpoints(testBaseURL).Stats(testBotID)
+	expected := fmt.Sprintf(testBaseURL+statsPath, testBotID)
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s", got, expected)
+	}
+}
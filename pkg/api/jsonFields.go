@@ -0,0 +1,79 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+)
+
+// JSONFields maps the JSON names of the fields of a struct type to the fields
+// encoding/json decodes them into, including the fields of embedded structs.
+type JSONFields map[string]reflect.StructField
+
+// NewJSONFields returns the JSONFields of the given struct type, skipping
+// unexported fields and fields tagged "-" as encoding/json does.
+func NewJSONFields(typ reflect.Type) JSONFields {
+	fields := make(JSONFields)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, tagged := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && !tagged && fieldType.Kind() == reflect.Struct {
+			for embeddedName, embeddedField := range NewJSONFields(fieldType) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedField
+				}
+			}
+
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fields[name] = field
+	}
+
+	return fields
+}
+
+// Lookup finds the field for the given JSON object key, matching names case
+// insensitively as encoding/json does.
+func (fields JSONFields) Lookup(key string) (reflect.StructField, bool) {
+	field, ok := fields[key]
+	if ok {
+		return field, true
+	}
+
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func jsonName(field reflect.StructField) (name string, tagged bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "-", true
+	}
+
+	name = strings.Split(tag, ",")[0]
+	if name == "" {
+		return field.Name, false
+	}
+
+	return name, true
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewJSONFields(t *testing.T) {
+	type embedded struct {
+		Embedded string `json:"embedded"`
+	}
+
+	type object struct {
+		*embedded
+		Named      string `json:"named,omitempty"`
+		Untagged   string
+		Ignored    string `json:"-"`
+		unexported string
+	}
+
+	fields := NewJSONFields(reflect.TypeOf(object{}))
+
+	for _, key := range []string{"embedded", "named", "Untagged", "NAMED"} {
+		if _, ok := fields.Lookup(key); !ok {
+			t.Errorf("Missing field for key %s", key)
+		}
+	}
+
+	for _, key := range []string{"Ignored", "-", "unexported"} {
+		if field, ok := fields.Lookup(key); ok {
+			t.Errorf("Unexpected field for key %s: %s", key, field.Name)
+		}
+	}
+}
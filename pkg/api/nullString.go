@@ -0,0 +1,63 @@
+package api
+
+import "encoding/json"
+
+const nullJSON = "null"
+
+// NullString is a string which may be null in the discord.bots.gg API, so an
+// absent value can be told apart from an empty one. The zero value is null.
+type NullString struct {
+	Value string
+	Valid bool // Valid is true if the string is not null.
+}
+
+// NewNullString returns a valid NullString with the given value.
+func NewNullString(value string) NullString {
+	return NullString{Value: value, Valid: true}
+}
+
+// ValueOrDefault returns the value of the NullString, or the given
+// defaultValue if it is null.
+func (nullString NullString) ValueOrDefault(defaultValue string) string {
+	if !nullString.Valid {
+		return defaultValue
+	}
+
+	return nullString.Value
+}
+
+// String satisfies the fmt.Stringer interface and returns the value of the
+// NullString, which is empty if it is null.
+func (nullString NullString) String() string {
+	return nullString.Value
+}
+
+// MarshalJSON satisfies the json.Marshaler interface, encoding a null
+// NullString as null.
+func (nullString NullString) MarshalJSON() ([]byte, error) {
+	if !nullString.Valid {
+		return []byte(nullJSON), nil
+	}
+
+	return json.Marshal(nullString.Value)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface, decoding null as a
+// null NullString.
+func (nullString *NullString) UnmarshalJSON(data []byte) error {
+	if string(data) == nullJSON {
+		*nullString = NullString{}
+		return nil
+	}
+
+	var value string
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	*nullString = NewNullString(value)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testNullStringValue = "testValue"
+
+func TestNullString_JSON(t *testing.T) {
+	tests := map[string]NullString{
+		`null`:        {},
+		`""`:          NewNullString(""),
+		`"testValue"`: NewNullString(testNullStringValue),
+	}
+
+	for encoded, expected := range tests {
+		var nullString NullString
+
+		err := json.Unmarshal([]byte(encoded), &nullString)
+		if err != nil {
+			t.Fatalf("Unexpected error decoding %s: %s", encoded, err)
+		}
+
+		if nullString != expected {
+			t.Errorf("Unexpected decoded %s. Got: %+v. Expected: %+v.", encoded, nullString, expected)
+		}
+
+		nullStringBytes, err := json.Marshal(nullString)
+		if err != nil {
+			t.Fatalf("Unexpected error encoding %+v: %s", nullString, err)
+		}
+
+		if string(nullStringBytes) != encoded {
+			t.Errorf("Unexpected encoded %+v. Got: %s. Expected: %s.", nullString, nullStringBytes, encoded)
+		}
+	}
+
+	var nullString NullString
+
+	err := json.Unmarshal([]byte(`1`), &nullString)
+	if err == nil {
+		t.Errorf("Expected error decoding a number")
+	}
+}
+
+func TestNullString_ValueOrDefault(t *testing.T) {
+	if got := (NullString{}).ValueOrDefault(testNullStringValue); got != testNullStringValue {
+		t.Errorf("Unexpected value of null. Got: %s. Expected: %s.", got, testNullStringValue)
+	}
+
+	if got := NewNullString("").ValueOrDefault(testNullStringValue); got != "" {
+		t.Errorf("Unexpected value of empty string: %s", got)
+	}
+
+	if got := NewNullString(testNullStringValue).String(); got != testNullStringValue {
+		t.Errorf("Unexpected string. Got: %s. Expected: %s.", got, testNullStringValue)
+	}
+}
+
+func TestBot_nullFields(t *testing.T) {
+	const botJSON = `{"discriminator":null,"website":"","owner":{"discriminator":"0001"}}`
+
+	bot := &Bot{}
+
+	err := json.Unmarshal([]byte(botJSON), bot)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding bot: %s", err)
+	}
+
+	if bot.Discriminator.Valid || !bot.Website.Valid || bot.Owner.Discriminator != NewNullString("0001") {
+		t.Errorf("Unexpected null fields: %+v %+v", bot, bot.Owner)
+	}
+
+	botBytes, err := json.Marshal(bot)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding bot: %s", err)
+	}
+
+	roundTrip := &Bot{}
+
+	err = json.Unmarshal(botBytes, roundTrip)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding encoded bot: %s", err)
+	}
+
+	if roundTrip.Discriminator != bot.Discriminator || roundTrip.Website != bot.Website || roundTrip.AvatarURL.Valid {
+		t.Errorf("Unexpected bot after round trip: %+v", roundTrip)
+	}
+}
@@ -19,6 +19,12 @@ type QueryParameters struct {
 	Order      string // Sorts the results in ASC or DESC order.
 }
 
+// RequiresAuthentication returns whether querying with the *QueryParameters
+// requires authentication.
+func (queryParameters *QueryParameters) RequiresAuthentication() bool {
+	return queryParameters != nil && queryParameters.Unverified
+}
+
 // String is the URL value-encoded representation of a *QueryParameters.
 func (queryParameters *QueryParameters) String() string {
 	values := make(url.Values)
@@ -35,3 +35,23 @@ func TestQueryParameters_String(t *testing.T) {
 		)
 	}
 }
+
+func TestQueryParameters_RequiresAuthentication(t *testing.T) {
+	var queryParameters *QueryParameters
+
+	if queryParameters.RequiresAuthentication() {
+		t.Errorf("Unexpected authentication required for nil QueryParameters")
+	}
+
+	queryParameters = &QueryParameters{}
+
+	if queryParameters.RequiresAuthentication() {
+		t.Errorf("Unexpected authentication required for verified bots")
+	}
+
+	queryParameters.Unverified = true
+
+	if !queryParameters.RequiresAuthentication() {
+		t.Errorf("Expected authentication required for unverified bots")
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// encodeWithExtra encodes the given object, an alias of a response struct
+// without its custom MarshalJSON method, adding the given extra fields which
+// the struct does not model.
+func encodeWithExtra(object interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	objectBytes, err := json.Marshal(object)
+	if err != nil || len(extra) == 0 {
+		return objectBytes, err
+	}
+
+	known := NewJSONFields(reflect.TypeOf(object).Elem())
+	keys := make([]string, 0, len(extra))
+
+	for key := range extra {
+		if _, ok := known.Lookup(key); !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	buffer := bytes.NewBuffer(bytes.TrimSuffix(objectBytes, []byte("}")))
+
+	for _, key := range keys {
+		if buffer.Len() > 1 {
+			buffer.WriteByte(',')
+		}
+
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(extra[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func newTestExtra() map[string]json.RawMessage {
+	return map[string]json.RawMessage{
+		"deleted": json.RawMessage(`false`),
+		"flags":   json.RawMessage(`{"beta":[1,2]}`),
+	}
+}
+
+func TestBot_MarshalJSON_extra(t *testing.T) {
+	bot := Bot{Username: testBotUsername1, Extra: newTestExtra()}
+
+	// Bots are encoded with their extra fields by value as well as by pointer.
+	botsBytes, err := json.Marshal([]Bot{bot})
+	if err != nil {
+		t.Fatalf("Unexpected error encoding bots: %s", err)
+	}
+
+	var decoded []map[string]json.RawMessage
+
+	err = json.Unmarshal(botsBytes, &decoded)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding encoded bots: %s", err)
+	}
+
+	if len(decoded) != 1 || string(decoded[0]["deleted"]) != "false" || string(decoded[0]["flags"]) != `{"beta":[1,2]}` {
+		t.Errorf("Unexpected encoded bots: %s", botsBytes)
+	}
+
+	botBytes, err := json.Marshal(&bot)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding bot: %s", err)
+	}
+
+	if expected := string(botsBytes[1 : len(botsBytes)-1]); string(botBytes) != expected {
+		t.Errorf("Unexpected bot encoded by pointer. Got: %s. Expected: %s.", botBytes, expected)
+	}
+}
+
+func TestPage_MarshalJSON_extra(t *testing.T) {
+	page := Page{
+		Count: 1,
+		Bots:  []*Bot{{Username: testBotUsername1, Extra: newTestExtra()}},
+		Extra: map[string]json.RawMessage{"next": json.RawMessage(`null`)},
+	}
+
+	pageBytes, err := json.Marshal(page)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding page: %s", err)
+	}
+
+	decoded := &struct {
+		Next json.RawMessage              `json:"next"`
+		Bots []map[string]json.RawMessage `json:"bots"`
+	}{}
+
+	err = json.Unmarshal(pageBytes, decoded)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding encoded page: %s", err)
+	}
+
+	if string(decoded.Next) != "null" || len(decoded.Bots) != 1 || string(decoded.Bots[0]["deleted"]) != "false" {
+		t.Errorf("Unexpected encoded page: %s", pageBytes)
+	}
+}
+
+func TestStatsResponse_MarshalJSON_extra(t *testing.T) {
+	statsResponse := StatsResponse{
+		Stats: &Stats{GuildCount: testGuildCount, ShardCount: testShardCount},
+		Extra: map[string]json.RawMessage{
+			"updated":    json.RawMessage(`true`),
+			"guildCount": json.RawMessage(`0`),
+		},
+	}
+
+	// Extra fields do not override modelled fields.
+	expected := `{"guildCount":100,"shardCount":5,"updated":true}`
+
+	if got := statsResponse.String(); got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s.", got, expected)
+	}
+
+	statsResponseBytes, err := json.Marshal(statsResponse)
+	if err != nil || string(statsResponseBytes) != expected {
+		t.Errorf("Unexpected stats response encoded by value: %s %v", statsResponseBytes, err)
+	}
+}
+
+func TestEncodeWithExtra(t *testing.T) {
+	type emptyAlias struct{}
+
+	encoded, err := encodeWithExtra(&emptyAlias{}, map[string]json.RawMessage{"b": json.RawMessage(`2`), "a": json.RawMessage(`"1"`)})
+	if err != nil {
+		t.Fatalf("Unexpected error encoding extra fields: %s", err)
+	}
+
+	if string(encoded) != `{"a":"1","b":2}` {
+		t.Errorf("Unexpected encoded extra fields: %s", encoded)
+	}
+}
@@ -0,0 +1,149 @@
+// Package collector provides an HTTP endpoint collecting the stats of bot
+// shards running in separate processes, which are aggregated and forwarded to
+// the discord.bots.gg API.
+package collector
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/discordbotsgg"
+)
+
+const maxRequestBodySize = 1 << 16
+
+// Collector is an http.Handler accepting POST requests with a JSON encoded
+// *api.StatsUpdate from each shard process. The shard stats are aggregated by
+// a *discordbotsgg.ShardStatsAggregator and forwarded to the API by Run.
+type Collector struct {
+	// Token, if set, is required in the Authorization header of requests.
+	Token string
+
+	// ErrorHandler, if set, is called with every error forwarding stats by
+	// Run. It should be set before calling Run.
+	ErrorHandler func(err error)
+
+	aggregator *discordbotsgg.ShardStatsAggregator
+	mutex      sync.Mutex
+	pending    bool
+}
+
+// New returns a new *Collector forwarding the shard stats of the given botID
+// with the provided *discordbotsgg.Client. Shard stats are posted according
+// to the given mode, and shards which have not pushed their stats for longer
+// than staleAfter are dropped.
+func New(client *discordbotsgg.Client, botID string, mode discordbotsgg.AggregateMode, staleAfter time.Duration) *Collector {
+	return &Collector{
+		aggregator: discordbotsgg.NewShardStatsAggregator(client, botID, mode, staleAfter),
+	}
+}
+
+// Aggregator returns the *discordbotsgg.ShardStatsAggregator of the
+// *Collector.
+func (collector *Collector) Aggregator() *discordbotsgg.ShardStatsAggregator {
+	return collector.aggregator
+}
+
+// ServeHTTP satisfies the http.Handler interface.
+func (collector *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !collector.authorized(r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	statsUpdate := &api.StatsUpdate{}
+
+	err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodySize)).Decode(statsUpdate)
+	if err != nil || statsUpdate.Stats == nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	_, _ = io.Copy(ioutil.Discard, r.Body)
+
+	if statsUpdate.ShardCount > 0 {
+		collector.aggregator.SetShardCount(statsUpdate.ShardCount)
+	}
+
+	collector.aggregator.Set(statsUpdate.ShardID, statsUpdate.GuildCount)
+
+	collector.mutex.Lock()
+	collector.pending = true
+	collector.mutex.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Run forwards the collected shard stats to the API every interval, when new
+// stats have been pushed since the last successful post, until the provided
+// context is done. It returns discordbotsgg.ErrInvalidInterval if the interval
+// is not positive.
+func (collector *Collector) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return discordbotsgg.ErrInvalidInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		collector.mutex.Lock()
+		pending := collector.pending
+		collector.mutex.Unlock()
+
+		if !pending {
+			continue
+		}
+
+		err := collector.Flush(ctx)
+		if err != nil && collector.ErrorHandler != nil {
+			collector.ErrorHandler(err)
+		}
+	}
+}
+
+// Flush forwards the collected shard stats to the API immediately.
+func (collector *Collector) Flush(ctx context.Context) error {
+	collector.mutex.Lock()
+	collector.pending = false
+	collector.mutex.Unlock()
+
+	_, err := collector.aggregator.Post(ctx)
+	if err != nil {
+		collector.mutex.Lock()
+		collector.pending = true
+		collector.mutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (collector *Collector) authorized(r *http.Request) bool {
+	if collector.Token == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(collector.Token)) == 1
+}
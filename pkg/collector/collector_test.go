@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/discordbotsgg"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const (
+	testBotID      = "12345"
+	testToken      = "testToken"
+	testInterval   = 5 * time.Millisecond
+	testStaleAfter = time.Minute
+)
+
+func newTestCollector(mode discordbotsgg.AggregateMode) (*Collector, func()) {
+	client := discordbotsgg.NewClient(mock.NewHTTPClient(), "")
+
+	return New(client, testBotID, mode, testStaleAfter), client.Close
+}
+
+func serveTestRequest(collector *Collector, method, body, token string) int {
+	req := httptest.NewRequest(method, "/stats", strings.NewReader(body))
+
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, req)
+
+	return recorder.Code
+}
+
+func TestCollector_ServeHTTP(t *testing.T) {
+	collector, closeClient := newTestCollector(discordbotsgg.AggregateTotal)
+	defer closeClient()
+
+	collector.Token = testToken
+
+	requests := []struct {
+		method   string
+		body     string
+		token    string
+		expected int
+	}{
+		{http.MethodGet, "", testToken, http.StatusMethodNotAllowed},
+		{http.MethodPost, `{"guildCount":1}`, "", http.StatusUnauthorized},
+		{http.MethodPost, `{"guildCount":`, testToken, http.StatusBadRequest},
+		{http.MethodPost, `{"shardID":1}`, testToken, http.StatusBadRequest},
+		{http.MethodPost, `{"guildCount":10}`, testToken, http.StatusAccepted},
+		{http.MethodPost, `{"guildCount":20,"shardCount":3,"shardID":1}`, testToken, http.StatusAccepted},
+	}
+
+	for _, request := range requests {
+		got := serveTestRequest(collector, request.method, request.body, request.token)
+		if got != request.expected {
+			t.Errorf("Unexpected status for %s %s. Got: %d. Expected: %d.", request.method, request.body, got, request.expected)
+		}
+	}
+
+	statsUpdate, err := collector.Aggregator().Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error aggregating stats: %s", err)
+	}
+
+	if statsUpdate.GuildCount != 30 || statsUpdate.ShardCount != 3 {
+		t.Errorf("Unexpected aggregate stats: %+v", statsUpdate.Stats)
+	}
+}
+
+func TestCollector_Run(t *testing.T) {
+	collector, closeClient := newTestCollector(discordbotsgg.AggregatePerShard)
+	defer closeClient()
+
+	var (
+		mutex  sync.Mutex
+		errs   []error
+		runErr error
+	)
+
+	collector.ErrorHandler = func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		errs = append(errs, err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		runErr = collector.Run(ctx, testInterval)
+	}()
+
+	serveTestRequest(collector, http.MethodPost, `{"guildCount":10}`, "")
+
+	time.Sleep(4 * testInterval)
+	cancelCtx()
+	<-done
+
+	if !errors.Is(runErr, context.Canceled) {
+		t.Errorf("Unexpected error from Run: %v", runErr)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(errs) != 0 {
+		t.Errorf("Unexpected errors forwarding stats: %v", errs)
+	}
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	if collector.pending {
+		t.Errorf("Unexpected pending stats after Run")
+	}
+}
+
+func TestCollector_Run_invalidInterval(t *testing.T) {
+	collector, closeClient := newTestCollector(discordbotsgg.AggregatePerShard)
+	defer closeClient()
+
+	err := collector.Run(context.Background(), 0)
+	if !errors.Is(err, discordbotsgg.ErrInvalidInterval) {
+		t.Errorf("Unexpected error from Run with zero interval: %v", err)
+	}
+}
+
+func TestCollector_Flush(t *testing.T) {
+	collector, closeClient := newTestCollector(discordbotsgg.AggregateTotal)
+	defer closeClient()
+
+	err := collector.Flush(context.Background())
+	if !errors.Is(err, discordbotsgg.ErrNoShardStats) {
+		t.Errorf("Unexpected error flushing without stats: %v", err)
+	}
+
+	if !collector.pending {
+		t.Errorf("Expected stats to remain pending after failed flush")
+	}
+}
@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/discordbotsgg"
+)
+
+// Pusher pushes the stats of a shard process to a *Collector.
+type Pusher struct {
+	// Token, if set, is sent in the Authorization header of requests.
+	Token string
+
+	httpClient   discordbotsgg.HTTPClient
+	collectorURL string
+}
+
+// NewPusher returns a new *Pusher pushing shard stats to the *Collector
+// served at the given collectorURL.
+func NewPusher(httpClient discordbotsgg.HTTPClient, collectorURL string) *Pusher {
+	return &Pusher{
+		httpClient:   httpClient,
+		collectorURL: collectorURL,
+	}
+}
+
+// Push pushes the given shard stats to the *Collector.
+func (pusher *Pusher) Push(ctx context.Context, statsUpdate *api.StatsUpdate) (err error) {
+	statsUpdateBytes, err := json.Marshal(statsUpdate)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pusher.collectorURL, bytes.NewReader(statsUpdateBytes))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if pusher.Token != "" {
+		req.Header.Set("Authorization", pusher.Token)
+	}
+
+	resp, err := pusher.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%s: %w", closeErr, err)
+				return
+			}
+
+			err = closeErr
+		}
+	}()
+
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf(
+			"unexpected response code: %d %s",
+			resp.StatusCode,
+			http.StatusText(resp.StatusCode),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/discordbotsgg"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+func TestPusher_Push(t *testing.T) {
+	collector, closeClient := newTestCollector(discordbotsgg.AggregateTotal)
+	defer closeClient()
+
+	collector.Token = testToken
+
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	pusher := NewPusher(server.Client(), server.URL)
+
+	statsUpdate := &api.StatsUpdate{Stats: &api.Stats{GuildCount: 10}, ShardID: 1}
+
+	err := pusher.Push(context.Background(), statsUpdate)
+	if err == nil {
+		t.Errorf("Expected error pushing without token")
+	}
+
+	pusher.Token = testToken
+
+	err = pusher.Push(context.Background(), statsUpdate)
+	if err != nil {
+		t.Fatalf("Unexpected error pushing stats: %s", err)
+	}
+
+	aggregate, err := collector.Aggregator().Stats(context.Background())
+	if err != nil || aggregate.GuildCount != 10 {
+		t.Errorf("Unexpected aggregate stats: %v %v", aggregate, err)
+	}
+
+	pusher = NewPusher(server.Client(), server.URL+"/%zz")
+
+	err = pusher.Push(context.Background(), statsUpdate)
+	if err == nil {
+		t.Errorf("Expected error pushing to invalid URL")
+	}
+}
+
+func Example() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := discordbotsgg.NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	// In the collector process, serve the *Collector and forward the
+	// aggregated stats to the API every interval.
+	collector := New(client, "botID", discordbotsgg.AggregateTotal, 10*time.Minute)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	go func() {
+		_ = collector.Run(ctx, time.Minute)
+	}()
+
+	server := httptest.NewServer(collector) // Substitute http.ListenAndServe here.
+	defer server.Close()
+
+	// In each shard process, push the stats of the shard to the collector.
+	pusher := NewPusher(&http.Client{}, server.URL)
+
+	err := pusher.Push(ctx, &api.StatsUpdate{Stats: &api.Stats{GuildCount: 100, ShardCount: 1}})
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	err = collector.Flush(ctx)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	fmt.Println("Stats forwarded")
+	// Output: Stats forwarded
+}
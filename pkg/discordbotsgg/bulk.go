@@ -0,0 +1,127 @@
+package discordbotsgg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+const defaultConcurrency = 4
+
+// BulkError is returned by QueryBotsByID when querying one or more bots
+// failed. It maps each failed botID to its error.
+type BulkError struct {
+	Errors map[string]error
+}
+
+// Error satisfies the error interface.
+func (bulkErr *BulkError) Error() string {
+	botIDs := make([]string, 0, len(bulkErr.Errors))
+
+	for botID := range bulkErr.Errors {
+		botIDs = append(botIDs, botID)
+	}
+
+	sort.Strings(botIDs)
+
+	errMessages := make([]string, len(botIDs))
+
+	for i, botID := range botIDs {
+		errMessages[i] = fmt.Sprintf("%s: %s", botID, bulkErr.Errors[botID])
+	}
+
+	return fmt.Sprintf("error querying %d bots: %s", len(botIDs), strings.Join(errMessages, "; "))
+}
+
+// WithConcurrency sets the number of concurrent queries made by
+// QueryBotsByID. All queries share the *Client query rate limit. The default
+// is 4.
+func WithConcurrency(concurrency int) CallOption {
+	return func(options *callOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// QueryBotsByID returns information about each of the given botIDs, querying
+// them concurrently. The returned map contains the bots which were queried
+// successfully. If any query failed, a *BulkError is returned alongside the
+// partial results, mapping each failed botID to its error.
+func (client *Client) QueryBotsByID(ctx context.Context, botIDs []string, sanitize bool, opts ...CallOption) (map[string]*api.Bot, error) {
+	concurrency := newCallOptions(opts).concurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
+	}
+
+	bots := make(map[string]*api.Bot, len(botIDs))
+	bulkErr := &BulkError{Errors: make(map[string]error)}
+	jobs := make(chan string)
+
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+	)
+
+	for i := 0; i < concurrency; i++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for botID := range jobs {
+				bot, err := client.QueryBotWithContext(ctx, botID, sanitize, opts...)
+
+				mutex.Lock()
+
+				if err != nil {
+					bulkErr.Errors[botID] = err
+				} else {
+					bots[botID] = bot
+				}
+
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	queued := queueBotIDs(ctx, botIDs, jobs)
+
+	close(jobs)
+	waitGroup.Wait()
+
+	for _, botID := range botIDs {
+		if _, ok := queued[botID]; !ok {
+			bulkErr.Errors[botID] = ctx.Err()
+		}
+	}
+
+	if len(bulkErr.Errors) > 0 {
+		return bots, bulkErr
+	}
+
+	return bots, nil
+}
+
+// queueBotIDs sends each unique botID on the jobs channel until the provided
+// context is done, returning the set of botIDs queued.
+func queueBotIDs(ctx context.Context, botIDs []string, jobs chan<- string) map[string]struct{} {
+	queued := make(map[string]struct{}, len(botIDs))
+
+	for _, botID := range botIDs {
+		if _, ok := queued[botID]; ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return queued
+		case jobs <- botID:
+			queued[botID] = struct{}{}
+		}
+	}
+
+	return queued
+}
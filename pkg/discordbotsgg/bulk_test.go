@@ -0,0 +1,120 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const testUnknownBotID = "unknown"
+
+func TestBulkError_Error(t *testing.T) {
+	bulkErr := &BulkError{
+		Errors: map[string]error{
+			"2": ErrNotFound,
+			"1": ErrRateLimited,
+		},
+	}
+
+	got := bulkErr.Error()
+	expected := "error querying 2 bots: 1: rate limited; 2: not found"
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s.", got, expected)
+	}
+}
+
+func TestClient_QueryBotsByID(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		requests int
+	)
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		requests++
+		mutex.Unlock()
+
+		if strings.Contains(req.URL.Path, testUnknownBotID) {
+			return newTestResponse(req, http.StatusNotFound, nil, ""), nil
+		}
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	botIDs := []string{"1", "2", "3", testUnknownBotID, "1"}
+
+	bots, err := client.QueryBotsByID(context.Background(), botIDs, true, WithConcurrency(2))
+
+	var bulkErr *BulkError
+
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("Unexpected error type: %T", err)
+	}
+
+	if len(bulkErr.Errors) != 1 || !errors.Is(bulkErr.Errors[testUnknownBotID], ErrNotFound) {
+		t.Errorf("Unexpected bulk errors: %v", bulkErr.Errors)
+	}
+
+	if len(bots) != 3 || bots["1"] == nil || bots["2"] == nil || bots["3"] == nil {
+		t.Errorf("Unexpected bots: %v", bots)
+	}
+
+	if requests != 4 {
+		t.Errorf("Unexpected requests. Got: %d. Expected: %d.", requests, 4)
+	}
+
+	bots, err = client.QueryBotsByID(context.Background(), []string{"1"}, true)
+	if err != nil || len(bots) != 1 {
+		t.Errorf("Unexpected result querying bots by ID: %v %v", bots, err)
+	}
+}
+
+func TestClient_QueryBotsByID_canceled(t *testing.T) {
+	client := newBlockedClient()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	bots, err := client.QueryBotsByID(ctx, []string{"1", "2"}, true)
+
+	var bulkErr *BulkError
+
+	if !errors.As(err, &bulkErr) || len(bots) != 0 {
+		t.Fatalf("Unexpected result querying bots with canceled context: %v %v", bots, err)
+	}
+
+	for botID, botErr := range bulkErr.Errors {
+		if !errors.Is(botErr, context.Canceled) {
+			t.Errorf("Unexpected error for %s: %v", botID, botErr)
+		}
+	}
+
+	if len(bulkErr.Errors) != 2 {
+		t.Errorf("Unexpected bulk errors: %v", bulkErr.Errors)
+	}
+}
+
+func ExampleClient_QueryBotsByID() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	bots, err := client.QueryBotsByID(context.Background(), []string{"botID"}, true, WithConcurrency(2))
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+	}
+
+	fmt.Printf("Bot: %s\n", bots["botID"])
+	// Output: Bot: Test Bot 1
+}
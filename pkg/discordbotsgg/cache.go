@@ -0,0 +1,331 @@
+package discordbotsgg
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	headerETag            = "ETag"
+	headerLastModified    = "Last-Modified"
+	headerIfNoneMatch     = "If-None-Match"
+	headerIfModifiedSince = "If-Modified-Since"
+)
+
+// CacheEntry is a cached API response.
+type CacheEntry struct {
+	// Body is the body of the response.
+	Body []byte
+
+	// Stored is the time the response was received or last validated.
+	Stored time.Time
+
+	// ETag is the ETag header of the response, if any.
+	ETag string
+
+	// LastModified is the Last-Modified header of the response, if any.
+	LastModified string
+}
+
+// Cache stores API responses keyed by endpoint URL, with the keys of
+// authenticated queries prefixed by "authenticated:". Implementations must be
+// safe for concurrent use, and must not modify a *CacheEntry once it has been
+// stored.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries
+// beyond its maximum number of entries. A *MemoryCache is safe for
+// concurrent use.
+type MemoryCache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	items      map[string]*list.Element
+	order      *list.List
+	now        func() time.Time
+}
+
+// NewMemoryCache returns a new *MemoryCache holding up to maxEntries entries
+// for at most ttl. A maxEntries below 1 does not limit the number of entries,
+// and a ttl of zero retains entries until they are evicted. The ttl is a hard
+// retention limit; how long a cached response is served as fresh is set with
+// WithCache.
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns the entry cached for the given key, if any.
+func (cache *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := element.Value.(*memoryCacheItem)
+
+	if cache.ttl > 0 && cache.now().Sub(item.entry.Stored) > cache.ttl {
+		cache.removeElement(element)
+		return nil, false
+	}
+
+	cache.order.MoveToFront(element)
+
+	return item.entry, true
+}
+
+// Set caches the given entry for the given key, evicting the least recently
+// used entry if the *MemoryCache is full.
+func (cache *MemoryCache) Set(key string, entry *CacheEntry) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.items[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		cache.order.MoveToFront(element)
+
+		return
+	}
+
+	cache.items[key] = cache.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+
+	if cache.maxEntries > 0 && cache.order.Len() > cache.maxEntries {
+		cache.removeElement(cache.order.Back())
+	}
+}
+
+// Delete removes the entry cached for the given key, if any.
+func (cache *MemoryCache) Delete(key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.items[key]; ok {
+		cache.removeElement(element)
+	}
+}
+
+// Len returns the number of cached entries, including expired entries which
+// have not been removed yet.
+func (cache *MemoryCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.order.Len()
+}
+
+// removeElement must be called with the mutex held.
+func (cache *MemoryCache) removeElement(element *list.Element) {
+	cache.order.Remove(element)
+	delete(cache.items, element.Value.(*memoryCacheItem).key)
+}
+
+// responseCache holds the Cache and freshness policy of a *Client.
+type responseCache struct {
+	cache                Cache
+	ttl                  time.Duration
+	staleWhileRevalidate time.Duration
+	mutex                sync.Mutex
+	revalidating         map[string]bool
+}
+
+// WithCache sets a Cache for query responses. Cached responses are served
+// without a request for ttl after they were received. For a further
+// staleWhileRevalidate, the cached response is still served while it is
+// refreshed in the background. Queries for a bot ID or query parameters not
+// cached, or cached for longer, wait on a new request. Refreshes of cached
+// responses with an ETag or Last-Modified header are conditional requests, and
+// a 304 Not Modified response renews the cached response without downloading
+// it again.
+func WithCache(cache Cache, ttl, staleWhileRevalidate time.Duration) Option {
+	return func(client *Client) {
+		client.responseCache = &responseCache{
+			cache:                cache,
+			ttl:                  ttl,
+			staleWhileRevalidate: staleWhileRevalidate,
+			revalidating:         make(map[string]bool),
+		}
+	}
+}
+
+// WithCacheBypass makes a query ignore cached responses. The response
+// received still replaces any cached response.
+func WithCacheBypass() CallOption {
+	return func(options *callOptions) {
+		options.bypassCache = true
+	}
+}
+
+// cachedGet returns the body of the response for the given queryURL, served
+// from the *Client Cache when fresh enough.
+func (client *Client) cachedGet(ctx context.Context, limiter Limiter, queryURL string, authenticated, bypass bool) ([]byte, error) {
+	cache := client.responseCache
+	if cache == nil {
+		return client.get(ctx, limiter, queryURL, authenticated)
+	}
+
+	key := cacheKey(queryURL, authenticated)
+
+	if bypass {
+		respBody, _, err := client.refresh(ctx, limiter, queryURL, authenticated, key, nil)
+		return respBody, err
+	}
+
+	endpoint := parseEndpointName(queryURL)
+
+	entry, ok := cache.cache.Get(key)
+	if !ok {
+		client.metrics.observeCache(endpoint, false)
+
+		respBody, _, err := client.refresh(ctx, limiter, queryURL, authenticated, key, nil)
+
+		return respBody, err
+	}
+
+	age := time.Since(entry.Stored)
+
+	if age <= cache.ttl {
+		client.metrics.observeCache(endpoint, true)
+		return entry.Body, nil
+	}
+
+	if age <= cache.ttl+cache.staleWhileRevalidate {
+		client.metrics.observeCache(endpoint, true)
+		client.revalidate(limiter, queryURL, authenticated, key, entry)
+
+		return entry.Body, nil
+	}
+
+	respBody, notModified, err := client.refresh(ctx, limiter, queryURL, authenticated, key, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	client.metrics.observeCache(endpoint, notModified)
+
+	return respBody, nil
+}
+
+// refresh requests the given queryURL and caches the response body. The
+// request is conditional on the given cached *CacheEntry, if any, and a 304
+// Not Modified response renews it, which is reported as notModified.
+func (client *Client) refresh(
+	ctx context.Context,
+	limiter Limiter,
+	queryURL string,
+	authenticated bool,
+	key string,
+	cached *CacheEntry,
+) (respBody []byte, notModified bool, err error) {
+	req, err := client.newGetRequest(ctx, queryURL, authenticated)
+	if err != nil {
+		return nil, false, err
+	}
+
+	setConditionalHeaders(req, cached)
+
+	resp, err := client.doSharedRequest(limiter, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	entry := &CacheEntry{
+		Body:         resp.body,
+		Stored:       time.Now(),
+		ETag:         resp.header.Get(headerETag),
+		LastModified: resp.header.Get(headerLastModified),
+	}
+
+	notModified = resp.statusCode == http.StatusNotModified
+
+	if notModified {
+		entry.Body = cached.Body
+
+		if entry.ETag == "" {
+			entry.ETag = cached.ETag
+		}
+
+		if entry.LastModified == "" {
+			entry.LastModified = cached.LastModified
+		}
+	}
+
+	client.responseCache.cache.Set(key, entry)
+
+	return entry.Body, notModified, nil
+}
+
+// revalidate refreshes the cached response for the given key in the
+// background, unless it is already being refreshed, until the *Client is
+// closed. A failed refresh leaves the cached response in place.
+func (client *Client) revalidate(limiter Limiter, queryURL string, authenticated bool, key string, cached *CacheEntry) {
+	cache := client.responseCache
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.revalidating[key] {
+		return
+	}
+
+	cache.revalidating[key] = true
+
+	go func() {
+		_, _, _ = client.refresh(client.ctx, limiter, queryURL, authenticated, key, cached)
+
+		cache.mutex.Lock()
+		delete(cache.revalidating, key)
+		cache.mutex.Unlock()
+	}()
+}
+
+// cacheKey returns the cache key of the given queryURL. Authenticated
+// responses may include unverified bots, so they are cached separately.
+func cacheKey(queryURL string, authenticated bool) string {
+	if authenticated {
+		return "authenticated:" + queryURL
+	}
+
+	return queryURL
+}
+
+// setConditionalHeaders makes the given *http.Request conditional on the
+// validators of the given cached *CacheEntry, if any.
+func setConditionalHeaders(req *http.Request, cached *CacheEntry) {
+	if cached == nil {
+		return
+	}
+
+	if cached.ETag != "" {
+		req.Header.Set(headerIfNoneMatch, cached.ETag)
+	}
+
+	if cached.LastModified != "" {
+		req.Header.Set(headerIfModifiedSince, cached.LastModified)
+	}
+}
+
+// isConditional returns whether the given *http.Request is conditional, so a
+// 304 Not Modified response is expected.
+func isConditional(req *http.Request) bool {
+	return req.Header.Get(headerIfNoneMatch) != "" || req.Header.Get(headerIfModifiedSince) != ""
+}
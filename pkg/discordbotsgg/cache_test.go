@@ -0,0 +1,283 @@
+package discordbotsgg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const (
+	testCacheEntries = 2
+	testCacheTTL     = time.Minute
+)
+
+type countingTransport struct {
+	mutex    sync.Mutex
+	requests int
+}
+
+func (transport *countingTransport) httpClient() *http.Client {
+	return newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		transport.mutex.Lock()
+		transport.requests++
+		transport.mutex.Unlock()
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+}
+
+func (transport *countingTransport) count() int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	return transport.requests
+}
+
+func revalidating(client *Client) bool {
+	client.responseCache.mutex.Lock()
+	defer client.responseCache.mutex.Unlock()
+
+	return len(client.responseCache.revalidating) > 0
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(testCacheEntries, testCacheTTL)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < testCacheEntries; i++ {
+		cache.Set(fmt.Sprint(i), &CacheEntry{Stored: now})
+	}
+
+	// Using the oldest entry makes the second entry the least recently used.
+	if _, ok := cache.Get("0"); !ok {
+		t.Fatalf("Missing cache entry")
+	}
+
+	cache.Set(fmt.Sprint(testCacheEntries), &CacheEntry{Stored: now})
+
+	if _, ok := cache.Get("1"); ok {
+		t.Errorf("Least recently used entry not evicted")
+	}
+
+	if cache.Len() != testCacheEntries {
+		t.Errorf("Unexpected cache length: %d", cache.Len())
+	}
+
+	cache.Delete("0")
+
+	if _, ok := cache.Get("0"); ok {
+		t.Errorf("Deleted entry still cached")
+	}
+
+	now = now.Add(testCacheTTL + time.Second)
+
+	if _, ok := cache.Get(fmt.Sprint(testCacheEntries)); ok {
+		t.Errorf("Expired entry still cached")
+	}
+
+	if cache.Len() != 0 {
+		t.Errorf("Unexpected cache length after expiry: %d", cache.Len())
+	}
+}
+
+func TestClient_QueryBotWithContext_cache(t *testing.T) {
+	transport := &countingTransport{}
+	cache := NewMemoryCache(0, 0)
+
+	client := NewClientWithOptions(
+		WithHTTPClient(transport.httpClient()),
+		WithCache(cache, testCacheTTL, testCacheTTL),
+	)
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		bot, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+		if err != nil {
+			t.Fatalf(queryBotErrorMessage, err)
+		}
+
+		if bot.ClientID == "" {
+			t.Errorf("Unexpected cached bot: %+v", bot)
+		}
+	}
+
+	if transport.count() != 1 {
+		t.Errorf("Unexpected requests for fresh cache entry: %d", transport.count())
+	}
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false, WithCacheBypass())
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if transport.count() != 2 {
+		t.Errorf("Unexpected requests bypassing cache: %d", transport.count())
+	}
+
+	key := cacheKey(client.endpoints().Bot(testBotID, false), false)
+
+	entry, _ := cache.Get(key)
+	cache.Set(key, &CacheEntry{Body: entry.Body, Stored: time.Now().Add(-testCacheTTL - time.Second)})
+
+	_, err = client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	// The stale entry is served immediately and revalidated in the background.
+	deadline := time.Now().Add(time.Second)
+
+	for revalidating(client) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if transport.count() != 3 {
+		t.Errorf("Unexpected requests revalidating stale entry: %d", transport.count())
+	}
+
+	cache.Set(key, &CacheEntry{Body: entry.Body, Stored: time.Now().Add(-3 * testCacheTTL)})
+
+	_, err = client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if transport.count() != 4 {
+		t.Errorf("Unexpected requests for expired entry: %d", transport.count())
+	}
+}
+
+func TestClient_Close_revalidating(t *testing.T) {
+	cache := NewMemoryCache(0, 0)
+
+	client := NewClientWithOptions(
+		WithHTTPClient(mock.NewHTTPClient()),
+		WithCache(cache, testCacheTTL, testCacheTTL),
+	)
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	key := cacheKey(client.endpoints().Bot(testBotID, false), false)
+
+	entry, _ := cache.Get(key)
+	cache.Set(key, &CacheEntry{Body: entry.Body, Stored: time.Now().Add(-testCacheTTL - time.Second)})
+
+	stopLimiter(client.QueryLimiter)
+	client.QueryLimiter = blockingLimiter{}
+
+	_, err = client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if !revalidating(client) {
+		t.Fatalf("Stale entry not revalidated")
+	}
+
+	// Closing the client stops the refresh waiting on the query rate limit.
+	client.Close()
+
+	deadline := time.Now().Add(time.Second)
+
+	for revalidating(client) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if revalidating(client) {
+		t.Errorf("Revalidation still running after Close")
+	}
+}
+
+func TestClient_QueryBotWithContext_conditional(t *testing.T) {
+	const (
+		testETag         = `"v1"`
+		testLastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	)
+
+	var (
+		mutex       sync.Mutex
+		conditional []http.Header
+	)
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if req.Header.Get("If-None-Match") == testETag {
+			conditional = append(conditional, req.Header)
+			return newTestResponse(req, http.StatusNotModified, nil, ""), nil
+		}
+
+		resp, err := mock.NewTransport().RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Header.Set("ETag", testETag)
+		resp.Header.Set("Last-Modified", testLastModified)
+
+		return resp, nil
+	})
+
+	cache := NewMemoryCache(0, 0)
+
+	client := NewClientWithOptions(WithHTTPClient(httpClient), WithCache(cache, 0, 0))
+	defer client.Close()
+
+	expected, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	bot, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if bot.ClientID != expected.ClientID || bot.Username != expected.Username {
+		t.Errorf("Unexpected bot from 304 response: %+v", bot)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(conditional) != 1 || conditional[0].Get("If-Modified-Since") != testLastModified {
+		t.Fatalf("Unexpected conditional requests: %v", conditional)
+	}
+
+	entry, ok := cache.Get(cacheKey(client.endpoints().Bot(testBotID, false), false))
+	if !ok || entry.ETag != testETag || entry.LastModified != testLastModified || len(entry.Body) == 0 {
+		t.Errorf("Unexpected cache entry after 304 response: %+v", entry)
+	}
+}
+
+func TestClient_QueryBotWithContext_notModified(t *testing.T) {
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return newTestResponse(req, http.StatusNotModified, nil, ""), nil
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	// A 304 response to an unconditional request is unexpected.
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err == nil {
+		t.Errorf("Expected error for unexpected 304 response")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	if cacheKey(testBotID, true) == cacheKey(testBotID, false) {
+		t.Errorf("Authenticated and unauthenticated queries share a cache key")
+	}
+}
@@ -0,0 +1,59 @@
+package discordbotsgg
+
+import "fmt"
+
+// CallOption configures a single call to a *Client method.
+type CallOption func(options *callOptions)
+
+type callOptions struct {
+	authenticate *bool
+	concurrency  int
+	bypassCache  bool
+}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	options := &callOptions{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithAuthentication sets whether the *Client BotToken is sent with a query.
+// By default, queries are authenticated when the *Client has a BotToken.
+func WithAuthentication(authenticate bool) CallOption {
+	return func(options *callOptions) {
+		options.authenticate = &authenticate
+	}
+}
+
+// authenticated returns whether a query should be authenticated, and an
+// error if authentication was requested without a BotToken.
+func (client *Client) authenticated(options *callOptions) (bool, error) {
+	if options.authenticate == nil {
+		return client.BotToken != "", nil
+	}
+
+	if *options.authenticate && client.BotToken == "" {
+		return false, ErrTokenRequired
+	}
+
+	return *options.authenticate, nil
+}
+
+// authenticationRequirer is implemented by query parameters which may require
+// authentication, such as *api.QueryParameters.
+type authenticationRequirer interface {
+	RequiresAuthentication() bool
+}
+
+func checkAuthentication(queryParameters fmt.Stringer, authenticated bool) error {
+	requirer, ok := queryParameters.(authenticationRequirer)
+	if !ok || authenticated || !requirer.RequiresAuthentication() {
+		return nil
+	}
+
+	return fmt.Errorf("query parameters require authentication: %w", ErrTokenRequired)
+}
@@ -0,0 +1,73 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+func TestWithAuthentication(t *testing.T) {
+	var authorization string
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		authorization = req.Header.Get("Authorization")
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	client := NewClient(httpClient, testBotToken)
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, true)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if authorization != testBotToken {
+		t.Errorf("Unexpected Authorization header. Got: %q. Expected: %q.", authorization, testBotToken)
+	}
+
+	_, err = client.QueryBotWithContext(context.Background(), testBotID, true, WithAuthentication(false))
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if authorization != "" {
+		t.Errorf("Unexpected Authorization header with authentication disabled: %q", authorization)
+	}
+
+	client.BotToken = ""
+
+	_, err = client.QueryBotWithContext(context.Background(), testBotID, true, WithAuthentication(true))
+	if !errors.Is(err, ErrTokenRequired) {
+		t.Errorf("Unexpected error requiring authentication without a token: %v", err)
+	}
+}
+
+func TestClient_QueryBots_unverified(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), testBotToken)
+	defer client.Close()
+
+	queryParameters := &api.QueryParameters{Unverified: true}
+
+	_, err := client.QueryBotsWithContext(context.Background(), queryParameters)
+	if err != nil {
+		t.Errorf(queryBotsErrorMessage, err)
+	}
+
+	_, err = client.QueryBotsWithContext(context.Background(), queryParameters, WithAuthentication(false))
+	if !errors.Is(err, ErrTokenRequired) {
+		t.Errorf("Unexpected error querying unverified bots without authentication: %v", err)
+	}
+
+	client.BotToken = ""
+
+	_, err = client.QueryBotsWithContext(context.Background(), queryParameters)
+	if !errors.Is(err, ErrTokenRequired) {
+		t.Errorf("Unexpected error querying unverified bots without a token: %v", err)
+	}
+}
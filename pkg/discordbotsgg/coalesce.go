@@ -0,0 +1,246 @@
+package discordbotsgg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// ErrUpdaterClosed is returned when updating with a closed
+// *CoalescingUpdater.
+const ErrUpdaterClosed = constError("updater closed")
+
+// CoalescingUpdater collapses rapid successive stats updates of a bot, such
+// as those triggered by guild events during a reconnect storm, into a single
+// post of the latest stats. Stats are posted once no update has been made for
+// the quiet period, or once the max delay has passed since the first pending
+// update, whichever is sooner. A *CoalescingUpdater is safe for concurrent
+// use.
+type CoalescingUpdater struct {
+	// ErrorHandler, if set, is called with every error posting stats in the
+	// background. A failed update remains pending and is retried with
+	// backoff until it is posted or replaced by a newer update. It should be
+	// set before calling Update.
+	ErrorHandler func(err error)
+
+	client      *Client
+	botID       string
+	quietPeriod time.Duration
+	maxDelay    time.Duration
+	ctx         context.Context
+	cancelCtx   context.CancelFunc
+	posting     chan struct{}
+	mutex       sync.Mutex
+	pending     *api.StatsUpdate
+	firstUpdate time.Time
+	timer       *time.Timer
+	generation  uint64
+	failures    int
+	retryPolicy *RetryPolicy
+	closed      bool
+}
+
+// NewCoalescingUpdater returns a new *CoalescingUpdater posting the stats of
+// the given botID after the given quietPeriod without updates, or after the
+// given maxDelay since the first pending update. A maxDelay of zero only
+// posts after the quiet period. Callers should call the
+// *CoalescingUpdater.Close method when done to post any pending update.
+func NewCoalescingUpdater(client *Client, botID string, quietPeriod, maxDelay time.Duration) *CoalescingUpdater {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	return &CoalescingUpdater{
+		client:      client,
+		botID:       botID,
+		quietPeriod: quietPeriod,
+		maxDelay:    maxDelay,
+		ctx:         ctx,
+		cancelCtx:   cancelCtx,
+		posting:     make(chan struct{}, 1),
+		retryPolicy: coalescingRetryPolicy(quietPeriod),
+	}
+}
+
+// coalescingRetryPolicy returns the *RetryPolicy of the backoff between
+// retries of failed posts, starting at the given quietPeriod.
+func coalescingRetryPolicy(quietPeriod time.Duration) *RetryPolicy {
+	retryPolicy := &RetryPolicy{
+		MinBackoff: quietPeriod,
+		MaxBackoff: defaultMaxBackoff,
+	}
+
+	if retryPolicy.MinBackoff <= 0 {
+		retryPolicy.MinBackoff = defaultMinBackoff
+	}
+
+	if retryPolicy.MaxBackoff < retryPolicy.MinBackoff {
+		retryPolicy.MaxBackoff = retryPolicy.MinBackoff
+	}
+
+	return retryPolicy
+}
+
+// Update records the given stats as the latest stats to post, replacing any
+// pending update. It does not block on posting.
+func (updater *CoalescingUpdater) Update(statsUpdate *api.StatsUpdate) error {
+	updater.mutex.Lock()
+	defer updater.mutex.Unlock()
+
+	if updater.closed {
+		return ErrUpdaterClosed
+	}
+
+	now := time.Now()
+
+	if updater.pending == nil {
+		updater.firstUpdate = now
+	}
+
+	updater.pending = copyStatsUpdate(statsUpdate)
+	updater.generation++
+
+	delay := updater.quietPeriod
+
+	if updater.maxDelay > 0 {
+		if remaining := updater.maxDelay - now.Sub(updater.firstUpdate); remaining < delay {
+			delay = nonNegative(remaining)
+		}
+	}
+
+	updater.schedule(delay)
+
+	return nil
+}
+
+// Flush posts the pending update immediately, if any, waiting for any post in
+// progress to finish first unless the provided context is done.
+func (updater *CoalescingUpdater) Flush(ctx context.Context) error {
+	err := updater.lockPosting(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer updater.unlockPosting()
+
+	updater.mutex.Lock()
+	statsUpdate := updater.takePending()
+	updater.mutex.Unlock()
+
+	return updater.send(ctx, statsUpdate)
+}
+
+// Close stops the *CoalescingUpdater, aborting any post in progress in the
+// background, and posts any pending update using the provided context.
+func (updater *CoalescingUpdater) Close(ctx context.Context) error {
+	updater.mutex.Lock()
+	updater.closed = true
+	updater.mutex.Unlock()
+
+	updater.cancelCtx()
+
+	return updater.Flush(ctx)
+}
+
+// post posts the pending update when the timer scheduled for the given
+// generation fires, unless the pending update has changed since.
+func (updater *CoalescingUpdater) post(generation uint64) {
+	if updater.lockPosting(updater.ctx) != nil {
+		return
+	}
+
+	updater.mutex.Lock()
+
+	if generation != updater.generation {
+		updater.mutex.Unlock()
+		updater.unlockPosting()
+
+		return
+	}
+
+	statsUpdate := updater.takePending()
+	updater.mutex.Unlock()
+
+	err := updater.send(updater.ctx, statsUpdate)
+	updater.unlockPosting()
+
+	// Posts aborted by Close are not errors; Close posts the update instead.
+	if err != nil && updater.ctx.Err() == nil && updater.ErrorHandler != nil {
+		updater.ErrorHandler(err)
+	}
+}
+
+// lockPosting waits until no other post is in progress, or until the provided
+// context is done.
+func (updater *CoalescingUpdater) lockPosting(ctx context.Context) error {
+	select {
+	case updater.posting <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (updater *CoalescingUpdater) unlockPosting() {
+	<-updater.posting
+}
+
+// send posts the given stats, if any. When posting fails and no newer update
+// has been made, the stats become pending again and are retried with
+// backoff, unless the *CoalescingUpdater is closed.
+func (updater *CoalescingUpdater) send(ctx context.Context, statsUpdate *api.StatsUpdate) error {
+	if statsUpdate == nil {
+		return nil
+	}
+
+	_, err := updater.client.UpdateWithContext(ctx, updater.botID, statsUpdate)
+
+	updater.mutex.Lock()
+	defer updater.mutex.Unlock()
+
+	if err == nil {
+		updater.failures = 0
+		return nil
+	}
+
+	updater.failures++
+
+	if updater.pending == nil {
+		updater.pending = statsUpdate
+		updater.firstUpdate = time.Now()
+
+		if !updater.closed {
+			updater.schedule(updater.retryPolicy.backoff(updater.failures))
+		}
+	}
+
+	return err
+}
+
+// takePending removes and returns the pending update, stopping any timer
+// scheduled to post it. The caller must hold updater.mutex.
+func (updater *CoalescingUpdater) takePending() *api.StatsUpdate {
+	statsUpdate := updater.pending
+	updater.pending = nil
+	updater.generation++
+
+	if updater.timer != nil {
+		updater.timer.Stop()
+	}
+
+	return statsUpdate
+}
+
+// schedule schedules posting the pending update after the given delay,
+// replacing any scheduled post. The caller must hold updater.mutex.
+func (updater *CoalescingUpdater) schedule(delay time.Duration) {
+	if updater.timer != nil {
+		updater.timer.Stop()
+	}
+
+	generation := updater.generation
+
+	updater.timer = time.AfterFunc(delay, func() {
+		updater.post(generation)
+	})
+}
@@ -0,0 +1,331 @@
+package discordbotsgg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const (
+	testQuietPeriod = 20 * time.Millisecond
+	testMaxDelay    = 60 * time.Millisecond
+)
+
+type testStatsRecorder struct {
+	mutex       sync.Mutex
+	guildCounts []int
+	statusCode  int
+}
+
+func (recorder *testStatsRecorder) httpClient() *http.Client {
+	return newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+
+		if recorder.statusCode != 0 && recorder.statusCode != http.StatusOK {
+			return newTestResponse(req, recorder.statusCode, nil, ""), nil
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		stats := &api.Stats{}
+
+		err = json.Unmarshal(body, stats)
+		if err != nil {
+			return nil, err
+		}
+
+		recorder.guildCounts = append(recorder.guildCounts, stats.GuildCount)
+
+		return newTestResponse(req, http.StatusOK, nil, string(body)), nil
+	})
+}
+
+func (recorder *testStatsRecorder) posted() []int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]int(nil), recorder.guildCounts...)
+}
+
+func (recorder *testStatsRecorder) setStatusCode(statusCode int) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.statusCode = statusCode
+}
+
+func waitForPosts(t *testing.T, recorder *testStatsRecorder, count int) []int {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		if posted := recorder.posted(); len(posted) >= count {
+			return posted
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for %d posts: %v", count, recorder.posted())
+
+	return nil
+}
+
+func TestCoalescingUpdater_Update(t *testing.T) {
+	recorder := &testStatsRecorder{}
+
+	client := NewClient(recorder.httpClient(), "")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, testBotID, testQuietPeriod, 0)
+
+	for guildCount := 1; guildCount <= testGuildCount; guildCount++ {
+		err := updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: guildCount}})
+		if err != nil {
+			t.Fatalf("Unexpected error updating stats: %s", err)
+		}
+	}
+
+	posted := waitForPosts(t, recorder, 1)
+	if len(posted) != 1 || posted[0] != testGuildCount {
+		t.Errorf("Unexpected posted guild counts: %v", posted)
+	}
+
+	err := updater.Close(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error closing updater: %s", err)
+	}
+
+	err = updater.Update(&api.StatsUpdate{Stats: &api.Stats{}})
+	if !errors.Is(err, ErrUpdaterClosed) {
+		t.Errorf("Unexpected error updating closed updater: %v", err)
+	}
+}
+
+func TestCoalescingUpdater_maxDelay(t *testing.T) {
+	recorder := &testStatsRecorder{}
+
+	client := NewClient(recorder.httpClient(), "")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, testBotID, testQuietPeriod, testMaxDelay)
+
+	defer func() {
+		_ = updater.Close(context.Background())
+	}()
+
+	// Updates arriving faster than the quiet period are still posted once the
+	// max delay has passed.
+	deadline := time.Now().Add(2 * testMaxDelay)
+
+	for guildCount := 1; time.Now().Before(deadline); guildCount++ {
+		err := updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: guildCount}})
+		if err != nil {
+			t.Fatalf("Unexpected error updating stats: %s", err)
+		}
+
+		time.Sleep(testQuietPeriod / 4)
+	}
+
+	waitForPosts(t, recorder, 1)
+}
+
+func TestCoalescingUpdater_Flush(t *testing.T) {
+	recorder := &testStatsRecorder{statusCode: http.StatusServiceUnavailable}
+
+	client := NewClient(recorder.httpClient(), "")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, testBotID, time.Hour, 0)
+
+	err := updater.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error flushing without updates: %s", err)
+	}
+
+	err = updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}})
+	if err != nil {
+		t.Fatalf("Unexpected error updating stats: %s", err)
+	}
+
+	err = updater.Flush(context.Background())
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("Unexpected error flushing during outage: %v", err)
+	}
+
+	recorder.setStatusCode(http.StatusOK)
+
+	// The failed update remains pending and is posted on Close.
+	err = updater.Close(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error closing updater: %s", err)
+	}
+
+	if posted := recorder.posted(); len(posted) != 1 || posted[0] != testGuildCount {
+		t.Errorf("Unexpected posted guild counts: %v", posted)
+	}
+}
+
+func TestCoalescingUpdater_retry(t *testing.T) {
+	recorder := &testStatsRecorder{statusCode: http.StatusServiceUnavailable}
+
+	client := NewClient(recorder.httpClient(), "")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, testBotID, testQuietPeriod, 0)
+
+	defer func() {
+		_ = updater.Close(context.Background())
+	}()
+
+	failed := make(chan error, 1)
+
+	updater.ErrorHandler = func(err error) {
+		select {
+		case failed <- err:
+		default:
+		}
+	}
+
+	err := updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}})
+	if err != nil {
+		t.Fatalf("Unexpected error updating stats: %s", err)
+	}
+
+	if err = <-failed; !errors.Is(err, ErrServer) {
+		t.Fatalf("Unexpected error posting during outage: %v", err)
+	}
+
+	recorder.setStatusCode(http.StatusOK)
+
+	// The failed update is retried without further updates.
+	posted := waitForPosts(t, recorder, 1)
+	if len(posted) != 1 || posted[0] != testGuildCount {
+		t.Errorf("Unexpected posted guild counts: %v", posted)
+	}
+}
+
+func TestCoalescingUpdater_post_stale(t *testing.T) {
+	recorder := &testStatsRecorder{}
+
+	client := NewClient(recorder.httpClient(), "")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, testBotID, time.Hour, 0)
+
+	err := updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}})
+	if err != nil {
+		t.Fatalf("Unexpected error updating stats: %s", err)
+	}
+
+	updater.mutex.Lock()
+	staleGeneration := updater.generation
+	updater.mutex.Unlock()
+
+	err = updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount + 1}})
+	if err != nil {
+		t.Fatalf("Unexpected error updating stats: %s", err)
+	}
+
+	// A timer which fired before the latest update does not post it before
+	// the quiet period.
+	updater.post(staleGeneration)
+
+	if posted := recorder.posted(); len(posted) != 0 {
+		t.Errorf("Unexpected posted guild counts from stale timer: %v", posted)
+	}
+
+	err = updater.Close(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error closing updater: %s", err)
+	}
+
+	if posted := recorder.posted(); len(posted) != 1 || posted[0] != testGuildCount+1 {
+		t.Errorf("Unexpected posted guild counts: %v", posted)
+	}
+}
+
+func TestCoalescingUpdater_Close_deadline(t *testing.T) {
+	const closeTimeout = 50 * time.Millisecond
+
+	started := make(chan struct{}, 1)
+
+	// The server never responds, so each post runs until its context is done.
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+
+		<-req.Context().Done()
+
+		return nil, req.Context().Err()
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, testBotID, time.Millisecond, 0)
+
+	err := updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}})
+	if err != nil {
+		t.Fatalf("Unexpected error updating stats: %s", err)
+	}
+
+	<-started
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancelCtx()
+
+	start := time.Now()
+
+	err = updater.Close(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error closing updater: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close ignored its deadline: %s", elapsed)
+	}
+}
+
+func TestCoalescingRetryPolicy(t *testing.T) {
+	if retryPolicy := coalescingRetryPolicy(0); retryPolicy.MinBackoff != defaultMinBackoff {
+		t.Errorf("Unexpected min backoff without quiet period: %s", retryPolicy.MinBackoff)
+	}
+
+	if retryPolicy := coalescingRetryPolicy(time.Hour); retryPolicy.MaxBackoff != time.Hour {
+		t.Errorf("Unexpected max backoff with long quiet period: %s", retryPolicy.MaxBackoff)
+	}
+}
+
+func ExampleNewCoalescingUpdater() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	updater := NewCoalescingUpdater(client, "botID", 5*time.Second, time.Minute)
+
+	defer func() {
+		_ = updater.Close(context.Background())
+	}()
+
+	// Update the stats from guild create and delete event handlers; only the
+	// latest stats are posted once the updates settle.
+	_ = updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: 99}})
+	_ = updater.Update(&api.StatsUpdate{Stats: &api.Stats{GuildCount: 100}})
+}
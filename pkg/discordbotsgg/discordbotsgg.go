@@ -20,54 +20,138 @@ const (
 
 	updateLimit     = 20
 	updateTimeframe = time.Second
+
+	defaultUserAgent = "go-discordbotsgg (https://github.com/ewohltman/go-discordbotsgg)"
 )
 
+// response is a successful response to a request.
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
 // HTTPClient is an interface to abstract HTTP client implementations.
 type HTTPClient interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
-// Client is a discord.bots.gg client.
+// Client is a discord.bots.gg client. QueryLimiter rate limits requests to
+// query bots and UpdateLimiter rate limits requests to update bot stats.
+// Failed requests are retried according to RetryPolicy, if set.
 type Client struct {
 	HTTPClient    HTTPClient
 	BotToken      string
-	queryLimiter  *time.Ticker
-	updateLimiter *time.Ticker
+	QueryLimiter  Limiter
+	UpdateLimiter Limiter
+	RetryPolicy   *RetryPolicy
+	Middleware    []Middleware
+	Logger        Logger
+	Tracer        Tracer
+
+	apiEndpoints   *api.Endpoints
+	userAgent      string
+	requestTimeout time.Duration
+	globalPause    globalPause
+	responseCache  *responseCache
+	flights        flightGroup
+	metrics        *Metrics
+	strictDecoding bool
+	driftHandler   func(drift *SchemaDrift)
+	retainRawJSON  bool
+	ctx            context.Context
+	cancelCtx      context.CancelFunc
 }
 
-// NewClient returns a new *Client with configured rate limiters. Callers
-// should call the *Client.Close method when done with the *Client to avoid
-// leaks.
+// NewClient returns a new *Client with token bucket rate limiters permitting
+// bursts up to the documented API rate limits. Callers should call the
+// *Client.Close method when done with the *Client to avoid leaks.
 func NewClient(httpClient HTTPClient, botToken string) *Client {
+	return NewClientWithOptions(WithHTTPClient(httpClient), WithBotToken(botToken))
+}
+
+// NewClientWithOptions returns a new *Client configured with the given
+// options. Unset options default to the same configuration as NewClient.
+// Callers should call the *Client.Close method when done with the *Client to
+// avoid leaks.
+func NewClientWithOptions(opts ...Option) *Client {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
 	client := &Client{
-		HTTPClient:    httpClient,
-		BotToken:      botToken,
-		queryLimiter:  time.NewTicker(queryTimeframe / queryLimit),
-		updateLimiter: time.NewTicker(updateTimeframe / updateLimit),
+		apiEndpoints: api.NewEndpoints(api.DefaultBaseURL),
+		userAgent:    defaultUserAgent,
+		ctx:          ctx,
+		cancelCtx:    cancelCtx,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.HTTPClient == nil {
+		client.HTTPClient = &http.Client{}
+	}
+
+	if client.QueryLimiter == nil {
+		client.QueryLimiter = NewTokenBucket(queryLimit, queryTimeframe)
+	}
+
+	if client.UpdateLimiter == nil {
+		client.UpdateLimiter = NewTokenBucket(updateLimit, updateTimeframe)
+	}
+
+	if client.Logger == nil {
+		client.Logger = nopLogger{}
+	}
+
+	if client.Tracer == nil {
+		client.Tracer = nopTracer{}
 	}
 
 	return client
 }
 
-// Close stops the *Client rate limiting time.Tickers to release resources.
+// Close stops the *Client rate limiters and any background cache refreshes
+// to release resources.
 func (client *Client) Close() {
-	client.queryLimiter.Stop()
-	client.updateLimiter.Stop()
+	if client.cancelCtx != nil {
+		client.cancelCtx()
+	}
+
+	stopLimiter(client.QueryLimiter)
+	stopLimiter(client.UpdateLimiter)
 }
 
 // QueryBot returns information about the given botID.
-func (client *Client) QueryBot(botID string, sanitize bool) (*api.Bot, error) {
-	return client.QueryBotWithContext(context.TODO(), botID, sanitize)
+func (client *Client) QueryBot(botID string, sanitize bool, opts ...CallOption) (*api.Bot, error) {
+	return client.QueryBotWithContext(context.TODO(), botID, sanitize, opts...)
 }
 
 // QueryBotWithContext returns information about the given botID using the
 // provided context.
-func (client *Client) QueryBotWithContext(ctx context.Context, botID string, sanitize bool) (*api.Bot, error) {
-	<-client.queryLimiter.C
+func (client *Client) QueryBotWithContext(
+	ctx context.Context,
+	botID string,
+	sanitize bool,
+	opts ...CallOption,
+) (bot *api.Bot, err error) {
+	ctx, span := client.startSpan(ctx, spanQueryBot)
+	span.SetAttribute(attributeBotID, botID)
+
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	options := newCallOptions(opts)
 
-	bot := &api.Bot{}
+	authenticated, err := client.authenticated(options)
+	if err != nil {
+		return nil, err
+	}
+
+	bot = &api.Bot{}
 
-	err := client.doGetRequest(ctx, api.BotEndpoint(botID, sanitize), bot)
+	err = client.doGetRequest(ctx, client.QueryLimiter, client.endpoints().Bot(botID, sanitize), authenticated, options, bot)
 	if err != nil {
 		return nil, err
 	}
@@ -76,17 +160,39 @@ func (client *Client) QueryBotWithContext(ctx context.Context, botID string, san
 }
 
 // QueryBots returns results using the provided parameters.
-func (client *Client) QueryBots(queryParameters fmt.Stringer) (*api.Page, error) {
-	return client.QueryBotsWithContext(context.TODO(), queryParameters)
+func (client *Client) QueryBots(queryParameters fmt.Stringer, opts ...CallOption) (*api.Page, error) {
+	return client.QueryBotsWithContext(context.TODO(), queryParameters, opts...)
 }
 
-// QueryBotsWithContext returns results using the provided parameters and context.
-func (client *Client) QueryBotsWithContext(ctx context.Context, queryParameters fmt.Stringer) (*api.Page, error) {
-	<-client.queryLimiter.C
+// QueryBotsWithContext returns results using the provided parameters and
+// context. An error wrapping ErrTokenRequired is returned if the parameters
+// require authentication and the query is not authenticated.
+func (client *Client) QueryBotsWithContext(
+	ctx context.Context,
+	queryParameters fmt.Stringer,
+	opts ...CallOption,
+) (page *api.Page, err error) {
+	ctx, span := client.startSpan(ctx, spanQueryBots)
+
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	options := newCallOptions(opts)
 
-	page := &api.Page{}
+	authenticated, err := client.authenticated(options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkAuthentication(queryParameters, authenticated)
+	if err != nil {
+		return nil, err
+	}
+
+	page = &api.Page{}
 
-	err := client.doGetRequest(ctx, api.BotsEndpoint(queryParameters), page)
+	err = client.doGetRequest(ctx, client.QueryLimiter, client.endpoints().Bots(queryParameters), authenticated, options, page)
 	if err != nil {
 		return nil, err
 	}
@@ -100,12 +206,30 @@ func (client *Client) Update(botID string, statsUpdate *api.StatsUpdate) (*api.S
 }
 
 // UpdateWithContext updates the given botID with the provided botStats and context.
-func (client *Client) UpdateWithContext(ctx context.Context, botID string, statsUpdate *api.StatsUpdate) (*api.StatsResponse, error) {
-	<-client.updateLimiter.C
+func (client *Client) UpdateWithContext(
+	ctx context.Context,
+	botID string,
+	statsUpdate *api.StatsUpdate,
+) (*api.StatsResponse, error) {
+	return client.update(ctx, botID, statsUpdate)
+}
+
+// update posts the given request object as the stats of the given botID.
+func (client *Client) update(
+	ctx context.Context,
+	botID string,
+	requestObject interface{},
+) (statsResponse *api.StatsResponse, err error) {
+	ctx, span := client.startSpan(ctx, spanUpdate)
+	span.SetAttribute(attributeBotID, botID)
+
+	defer func() {
+		endSpan(span, err)
+	}()
 
-	statsResponse := &api.StatsResponse{}
+	statsResponse = &api.StatsResponse{}
 
-	err := client.doPostRequest(ctx, api.StatsEndpoint(botID), statsUpdate, statsResponse)
+	err = client.doPostRequest(ctx, client.UpdateLimiter, client.endpoints().Stats(botID), requestObject, statsResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -113,16 +237,67 @@ func (client *Client) UpdateWithContext(ctx context.Context, botID string, stats
 	return statsResponse, nil
 }
 
-func (client *Client) doGetRequest(ctx context.Context, queryURL string, responseObject interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+// endpoints returns the *api.Endpoints of the *Client, defaulting to the
+// discord.bots.gg API for a *Client not created by a constructor.
+func (client *Client) endpoints() *api.Endpoints {
+	if client.apiEndpoints == nil {
+		return api.NewEndpoints(api.DefaultBaseURL)
+	}
+
+	return client.apiEndpoints
+}
+
+func (client *Client) doGetRequest(
+	ctx context.Context,
+	limiter Limiter,
+	queryURL string,
+	authenticated bool,
+	options *callOptions,
+	responseObject interface{},
+) error {
+	respBody, err := client.cachedGet(ctx, limiter, queryURL, authenticated, options.bypassCache)
 	if err != nil {
 		return err
 	}
 
-	return client.doRequest(req, responseObject)
+	return client.decode(respBody, responseObject)
+}
+
+func (client *Client) get(ctx context.Context, limiter Limiter, queryURL string, authenticated bool) ([]byte, error) {
+	req, err := client.newGetRequest(ctx, queryURL, authenticated)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.doSharedRequest(limiter, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.body, nil
+}
+
+func (client *Client) newGetRequest(ctx context.Context, queryURL string, authenticated bool) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client.setUserAgent(req)
+
+	if authenticated {
+		req.Header.Set("Authorization", client.BotToken)
+	}
+
+	return req, nil
 }
 
-func (client *Client) doPostRequest(ctx context.Context, queryURL string, requestObject, responseObject interface{}) error {
+func (client *Client) doPostRequest(
+	ctx context.Context,
+	limiter Limiter,
+	queryURL string,
+	requestObject, responseObject interface{},
+) error {
 	requestObjectBytes, err := json.Marshal(requestObject)
 	if err != nil {
 		return err
@@ -133,19 +308,142 @@ func (client *Client) doPostRequest(ctx context.Context, queryURL string, reques
 		return err
 	}
 
+	client.setUserAgent(req)
 	req.Header.Set("Authorization", client.BotToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.ContentLength = int64(len(requestObjectBytes))
 
-	return client.doRequest(req, responseObject)
+	resp, err := client.doRequest(limiter, req)
+	if err != nil {
+		return err
+	}
+
+	return client.decode(resp.body, responseObject)
+}
+
+func (client *Client) setUserAgent(req *http.Request) {
+	if client.userAgent != "" {
+		req.Header.Set("User-Agent", client.userAgent)
+	}
+}
+
+// decode decodes the given response body into the given responseObject,
+// retaining the raw response when configured to.
+func (client *Client) decode(respBody []byte, responseObject interface{}) error {
+	err := json.Unmarshal(respBody, responseObject)
+	if err != nil {
+		return err
+	}
+
+	if client.retainRawJSON {
+		return retainRawJSON(respBody, responseObject)
+	}
+
+	return nil
+}
+
+// doRequest performs the given *http.Request, waiting on the provided Limiter
+// before each attempt and retrying according to the *Client RetryPolicy. It
+// returns the successful response.
+func (client *Client) doRequest(limiter Limiter, req *http.Request) (*response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		event := newRequestEvent(req, attempt)
+
+		waitCtx, waitSpan := client.startSpan(ctx, spanLimiterWait)
+		waitStart := time.Now()
+		err := client.waitLimiters(waitCtx, limiter)
+		event.LimiterWait = time.Since(waitStart)
+		endSpan(waitSpan, err)
+
+		if err != nil {
+			event.Err = err
+			client.logRequest(event)
+			client.metrics.observeLimiterWait(event)
+
+			return nil, err
+		}
+
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.doTracedAttempt(limiter, attemptReq, event)
+		client.logRequest(event)
+		client.metrics.observeRequest(event)
+
+		if err == nil {
+			client.reportDrift(req.URL.String(), resp)
+			return resp, nil
+		}
+
+		delay, retry := client.RetryPolicy.retryDelay(ctx, attempt, err)
+		if !retry || (req.Body != nil && req.GetBody == nil) {
+			return nil, err
+		}
+
+		err = sleepWithContext(ctx, delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doTracedAttempt performs doAttempt in a round trip span, recording the
+// attempt in the given *RequestEvent.
+func (client *Client) doTracedAttempt(limiter Limiter, req *http.Request, event *RequestEvent) (*response, error) {
+	ctx, span := client.startSpan(req.Context(), spanRoundTrip)
+	span.SetAttribute(attributeMethod, event.Method)
+	span.SetAttribute(attributeURL, event.URL)
+	span.SetAttribute(attributeEndpoint, event.Endpoint)
+	span.SetAttribute(attributeAttempt, event.Attempt)
+
+	req = req.WithContext(ctx)
+	injectTraceParent(req, span)
+
+	attemptStart := time.Now()
+	resp, err := client.doAttempt(limiter, req)
+	event.Duration = time.Since(attemptStart)
+	event.StatusCode, event.Err = statusCode(resp, err), err
+
+	if event.StatusCode != 0 {
+		span.SetAttribute(attributeStatusCode, event.StatusCode)
+	}
+
+	endSpan(span, err)
+
+	return resp, err
 }
 
-func (client *Client) doRequest(req *http.Request, responseObject interface{}) (err error) {
-	resp, err := client.HTTPClient.Do(req)
+// waitLimiters waits out any global rate limit and then on the given Limiter.
+func (client *Client) waitLimiters(ctx context.Context, limiter Limiter) error {
+	err := client.globalPause.wait(ctx)
 	if err != nil {
 		return err
 	}
 
+	return limiter.Wait(ctx)
+}
+
+// doAttempt performs a single attempt of the given *http.Request. A 304 Not
+// Modified response to a conditional request is successful.
+func (client *Client) doAttempt(limiter Limiter, req *http.Request) (_ *response, err error) {
+	if client.requestTimeout > 0 {
+		ctx, cancelCtx := context.WithTimeout(req.Context(), client.requestTimeout)
+		defer cancelCtx()
+
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := client.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client.observeRateLimit(limiter, resp)
+
 	defer func() {
 		closeErr := resp.Body.Close()
 		if closeErr != nil {
@@ -160,16 +458,35 @@ func (client *Client) doRequest(req *http.Request, responseObject interface{}) (
 
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && !(resp.StatusCode == http.StatusNotModified && isConditional(req)) {
+		return nil, newAPIError(req, resp, respBody)
+	}
+
+	return &response{
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       respBody,
+	}, nil
+}
+
+// rewindRequest returns the *http.Request to send for the given attempt. The
+// first attempt uses the original request, later attempts use a clone with
+// its body rewound.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.GetBody == nil {
+		return req, nil
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"unexpected response code: %d %s",
-			resp.StatusCode,
-			http.StatusText(resp.StatusCode),
-		)
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
 	}
 
-	return json.Unmarshal(respBody, responseObject)
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+
+	return attemptReq, nil
 }
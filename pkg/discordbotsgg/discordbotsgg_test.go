@@ -2,7 +2,11 @@ package discordbotsgg
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,7 +15,8 @@ import (
 )
 
 const (
-	testBotID = "12345"
+	testBotID    = "12345"
+	testBotToken = "testBotToken"
 
 	testParameterPage     = 1
 	testParameterLimit    = 1
@@ -25,6 +30,49 @@ const (
 	updateBotStatsErrorMessage = "Error updating bot stats: %s"
 )
 
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (rt roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt(req)
+}
+
+func newTestHTTPClient(roundTrip roundTripperFunc) *http.Client {
+	return &http.Client{Transport: roundTrip}
+}
+
+func newTestResponse(req *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		StatusCode:    statusCode,
+		Status:        http.StatusText(statusCode),
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+type blockingLimiter struct{}
+
+func (blockingLimiter) Wait(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func newBlockedClient() *Client {
+	client := NewClient(mock.NewHTTPClient(), "")
+	client.Close()
+
+	client.QueryLimiter = blockingLimiter{}
+	client.UpdateLimiter = blockingLimiter{}
+
+	return client
+}
+
 func TestNewClient(t *testing.T) {
 	client := NewClient(mock.NewHTTPClient(), "")
 	defer client.Close()
@@ -81,16 +129,18 @@ func BenchmarkClient_QueryBot(b *testing.B) {
 	duration := time.Since(start).Seconds()
 	actualRPS := float64(b.N) / duration
 	maxRPS := float64(queryLimit) / queryTimeframe.Seconds()
+	maxRequests := float64(queryLimit) + duration*maxRPS
 
 	b.Logf(
-		"Requests: %d, Seconds: %f, RPS: %f, Max RPS: %f",
+		"Requests: %d, Seconds: %f, RPS: %f, Max RPS: %f, Max Requests: %f",
 		b.N,
 		duration,
 		actualRPS,
 		maxRPS,
+		maxRequests,
 	)
 
-	if actualRPS > maxRPS {
+	if float64(b.N) > maxRequests {
 		b.Errorf("Failed to enforce rate limit")
 	}
 }
@@ -148,7 +198,7 @@ func ExampleClient_QueryBotWithContext() {
 }
 
 func TestClient_QueryBots(t *testing.T) {
-	client := NewClient(mock.NewHTTPClient(), "")
+	client := NewClient(mock.NewHTTPClient(), testBotToken)
 	defer client.Close()
 
 	_, err := client.QueryBots(&api.QueryParameters{})
@@ -187,7 +237,7 @@ func BenchmarkClient_QueryBots(b *testing.B) {
 		Order:      "DESC",
 	}
 
-	client := NewClient(mock.NewHTTPClient(), "")
+	client := NewClient(mock.NewHTTPClient(), testBotToken)
 	defer client.Close()
 
 	start := time.Now()
@@ -202,16 +252,18 @@ func BenchmarkClient_QueryBots(b *testing.B) {
 	duration := time.Since(start).Seconds()
 	actualRPS := float64(b.N) / duration
 	maxRPS := float64(queryLimit) / queryTimeframe.Seconds()
+	maxRequests := float64(queryLimit) + duration*maxRPS
 
 	b.Logf(
-		"Requests: %d, Seconds: %f, RPS: %f, Max RPS: %f",
+		"Requests: %d, Seconds: %f, RPS: %f, Max RPS: %f, Max Requests: %f",
 		b.N,
 		duration,
 		actualRPS,
 		maxRPS,
+		maxRequests,
 	)
 
-	if actualRPS > maxRPS {
+	if float64(b.N) > maxRequests {
 		b.Errorf("Failed to enforce rate limit")
 	}
 }
@@ -250,7 +302,7 @@ func ExampleClient_QueryBots() {
 }
 
 func TestClient_QueryBotsWithContext(t *testing.T) {
-	client := NewClient(mock.NewHTTPClient(), "")
+	client := NewClient(mock.NewHTTPClient(), testBotToken)
 	defer client.Close()
 
 	_, err := client.QueryBotsWithContext(context.Background(), &api.QueryParameters{})
@@ -359,16 +411,18 @@ func BenchmarkClient_Update(b *testing.B) {
 	duration := time.Since(start).Seconds()
 	actualRPS := float64(b.N) / duration
 	maxRPS := float64(updateLimit) / updateTimeframe.Seconds()
+	maxRequests := float64(updateLimit) + duration*maxRPS
 
 	b.Logf(
-		"Requests: %d, Seconds: %f, RPS: %f, Max RPS: %f",
+		"Requests: %d, Seconds: %f, RPS: %f, Max RPS: %f, Max Requests: %f",
 		b.N,
 		duration,
 		actualRPS,
 		maxRPS,
+		maxRequests,
 	)
 
-	if actualRPS > maxRPS {
+	if float64(b.N) > maxRequests {
 		b.Errorf("Failed to enforce rate limit")
 	}
 }
@@ -463,3 +517,25 @@ func ExampleClient_UpdateWithContext() {
 	fmt.Printf("%s", botStatsResponse)
 	// Output: {"guildCount":100,"shardCount":5}
 }
+
+func TestClient_CanceledContext(t *testing.T) {
+	client := newBlockedClient()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	_, err := client.QueryBotWithContext(ctx, testBotID, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error querying bot with canceled context: %v", err)
+	}
+
+	_, err = client.QueryBotsWithContext(ctx, &api.QueryParameters{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error querying bots with canceled context: %v", err)
+	}
+
+	_, err = client.UpdateWithContext(ctx, testBotID, &api.StatsUpdate{Stats: &api.Stats{}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error updating bot stats with canceled context: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package discordbotsgg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// ErrBadRequest matches an *APIError with a 400 Bad Request status code.
+	ErrBadRequest = constError("bad request")
+
+	// ErrUnauthorized matches an *APIError with a 401 Unauthorized status
+	// code, typically caused by a missing or invalid API token.
+	ErrUnauthorized = constError("unauthorized")
+
+	// ErrForbidden matches an *APIError with a 403 Forbidden status code.
+	ErrForbidden = constError("forbidden")
+
+	// ErrNotFound matches an *APIError with a 404 Not Found status code,
+	// typically caused by querying an unknown bot.
+	ErrNotFound = constError("not found")
+
+	// ErrRateLimited matches an *APIError with a 429 Too Many Requests status
+	// code.
+	ErrRateLimited = constError("rate limited")
+
+	// ErrServer matches an *APIError with a 5xx status code.
+	ErrServer = constError("server error")
+
+	// ErrTokenRequired is returned when an authenticated request is made by a
+	// *Client without a BotToken.
+	ErrTokenRequired = constError("API token required")
+
+	// ErrInvalidInterval is returned when running a periodic task with an
+	// interval which is not positive.
+	ErrInvalidInterval = constError("interval must be positive")
+)
+
+type constError string
+
+func (err constError) Error() string {
+	return string(err)
+}
+
+// APIError is returned when the discord.bots.gg API responds with an
+// unexpected status code. It can be matched against the sentinel errors in
+// this package using errors.Is.
+type APIError struct {
+	StatusCode int         // The HTTP status code of the response.
+	Message    string      // The error message decoded from the response body, if any.
+	Method     string      // The HTTP method of the request.
+	URL        string      // The URL of the request.
+	Header     http.Header // The headers of the response.
+	Body       []byte      // The raw response body.
+}
+
+func newAPIError(req *http.Request, resp *http.Response, respBody []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Header:     resp.Header,
+		Body:       respBody,
+	}
+
+	errorBody := &struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}{}
+
+	if json.Unmarshal(respBody, errorBody) == nil {
+		apiErr.Message = errorBody.Message
+
+		if apiErr.Message == "" {
+			apiErr.Message = errorBody.Error
+		}
+	}
+
+	return apiErr
+}
+
+// Error satisfies the error interface.
+func (apiErr *APIError) Error() string {
+	errMessage := fmt.Sprintf(
+		"%s %s: unexpected response code: %d %s",
+		apiErr.Method,
+		apiErr.URL,
+		apiErr.StatusCode,
+		http.StatusText(apiErr.StatusCode),
+	)
+
+	if apiErr.Message != "" {
+		errMessage = fmt.Sprintf("%s: %s", errMessage, apiErr.Message)
+	}
+
+	return errMessage
+}
+
+// Is reports whether the *APIError matches the given target sentinel error.
+func (apiErr *APIError) Is(target error) bool {
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest:
+		return target == ErrBadRequest
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+
+	return apiErr.StatusCode >= http.StatusInternalServerError && target == ErrServer
+}
@@ -0,0 +1,104 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+const testErrorMessage = "Unknown bot"
+
+func TestConstError_Error(t *testing.T) {
+	got := ErrNotFound.Error()
+	expected := "not found"
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s.", got, expected)
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	apiErr := &APIError{
+		StatusCode: http.StatusNotFound,
+		Method:     http.MethodGet,
+		URL:        api.BotEndpoint(testBotID, false),
+	}
+
+	got := apiErr.Error()
+	expected := "GET " + api.BotEndpoint(testBotID, false) + ": unexpected response code: 404 Not Found"
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s.", got, expected)
+	}
+
+	apiErr.Message = testErrorMessage
+
+	got = apiErr.Error()
+	expected += ": " + testErrorMessage
+
+	if got != expected {
+		t.Errorf("Unexpected result. Got: %s. Expected: %s.", got, expected)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	sentinels := map[int]error{
+		http.StatusBadRequest:          ErrBadRequest,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrForbidden,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusInternalServerError: ErrServer,
+		http.StatusBadGateway:          ErrServer,
+	}
+
+	for statusCode, sentinel := range sentinels {
+		apiErr := &APIError{StatusCode: statusCode}
+
+		if !errors.Is(apiErr, sentinel) {
+			t.Errorf("Expected status code %d to match %q", statusCode, sentinel)
+		}
+
+		if statusCode != http.StatusNotFound && errors.Is(apiErr, ErrNotFound) {
+			t.Errorf("Unexpected status code %d match for %q", statusCode, ErrNotFound)
+		}
+	}
+}
+
+func TestClient_doRequest_apiError(t *testing.T) {
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		header := make(http.Header)
+		header.Set("Content-Type", "application/json")
+
+		return newTestResponse(req, http.StatusNotFound, header, `{"message":"`+testErrorMessage+`"}`), nil
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected error querying unknown bot: %v", err)
+	}
+
+	var apiErr *APIError
+
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Unexpected error type: %T", err)
+	}
+
+	if apiErr.Message != testErrorMessage {
+		t.Errorf("Unexpected error message: %s", apiErr.Message)
+	}
+
+	if apiErr.Method != http.MethodGet || apiErr.URL != api.BotEndpoint(testBotID, false) {
+		t.Errorf("Unexpected request: %s %s", apiErr.Method, apiErr.URL)
+	}
+
+	if apiErr.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected response headers: %v", apiErr.Header)
+	}
+}
@@ -0,0 +1,133 @@
+package discordbotsgg
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// flight is an in-flight request shared by every caller making an identical
+// request until it completes.
+type flight struct {
+	done      chan struct{}
+	resp      *response
+	err       error
+	waiters   int
+	cancelCtx context.CancelFunc
+}
+
+// flightGroup tracks the in-flight requests of a *Client. The zero value is
+// ready to use.
+type flightGroup struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+}
+
+// detachedContext carries the values of its parent context without its
+// deadline and cancellation, so a shared request outlives the caller which
+// started it.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// doSharedRequest performs the given GET *http.Request like doRequest, except
+// that identical requests made while it is in flight share its response
+// instead of making a request of their own. Each caller stops waiting when
+// its own context is done, and the shared request is cancelled once every
+// caller has stopped waiting.
+func (client *Client) doSharedRequest(limiter Limiter, req *http.Request) (*response, error) {
+	group := &client.flights
+	key := flightKey(req)
+
+	group.mutex.Lock()
+
+	if group.flights == nil {
+		group.flights = make(map[string]*flight)
+	}
+
+	sharedFlight, ok := group.flights[key]
+	if !ok {
+		ctx, cancelCtx := context.WithCancel(detachedContext{Context: req.Context()})
+
+		sharedFlight = &flight{
+			done:      make(chan struct{}),
+			cancelCtx: cancelCtx,
+		}
+
+		group.flights[key] = sharedFlight
+
+		go group.fly(client, limiter, req.WithContext(ctx), key, sharedFlight)
+	}
+
+	sharedFlight.waiters++
+
+	group.mutex.Unlock()
+
+	return group.wait(req.Context(), key, sharedFlight)
+}
+
+func (group *flightGroup) fly(client *Client, limiter Limiter, req *http.Request, key string, sharedFlight *flight) {
+	resp, err := client.doRequest(limiter, req)
+
+	group.mutex.Lock()
+	group.forget(key, sharedFlight)
+	sharedFlight.resp, sharedFlight.err = resp, err
+	group.mutex.Unlock()
+
+	close(sharedFlight.done)
+	sharedFlight.cancelCtx()
+}
+
+func (group *flightGroup) wait(ctx context.Context, key string, sharedFlight *flight) (*response, error) {
+	select {
+	case <-sharedFlight.done:
+		return sharedFlight.resp, sharedFlight.err
+	case <-ctx.Done():
+	}
+
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+
+	sharedFlight.waiters--
+
+	if sharedFlight.waiters == 0 {
+		group.forget(key, sharedFlight)
+		sharedFlight.cancelCtx()
+	}
+
+	return nil, ctx.Err()
+}
+
+// forget removes the given flight so later requests start a new one. It must
+// be called with the mutex held.
+func (group *flightGroup) forget(key string, sharedFlight *flight) {
+	if group.flights[key] == sharedFlight {
+		delete(group.flights, key)
+	}
+}
+
+// flightKey returns the key of the given *http.Request, which identical
+// requests share.
+func flightKey(req *http.Request) string {
+	return strings.Join([]string{
+		req.Method,
+		req.URL.String(),
+		req.Header.Get("Authorization"),
+		req.Header.Get(headerIfNoneMatch),
+		req.Header.Get(headerIfModifiedSince),
+	}, "\n")
+}
@@ -0,0 +1,223 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const testWaiters = 50
+
+// gatedTransport counts requests and holds each one until released.
+type gatedTransport struct {
+	mutex    sync.Mutex
+	requests int
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func newGatedTransport() *gatedTransport {
+	return &gatedTransport{
+		started: make(chan struct{}, testWaiters),
+		release: make(chan struct{}),
+	}
+}
+
+func (transport *gatedTransport) httpClient() *http.Client {
+	return newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		transport.mutex.Lock()
+		transport.requests++
+		transport.mutex.Unlock()
+
+		transport.started <- struct{}{}
+
+		select {
+		case <-transport.release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+}
+
+func (transport *gatedTransport) count() int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	return transport.requests
+}
+
+func waitForWaiters(t *testing.T, client *Client, waiters int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		client.flights.mutex.Lock()
+
+		total := 0
+
+		for _, sharedFlight := range client.flights.flights {
+			total += sharedFlight.waiters
+		}
+
+		client.flights.mutex.Unlock()
+
+		if total == waiters {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("Timed out waiting for %d waiters", waiters)
+}
+
+func TestClient_doSharedRequest(t *testing.T) {
+	transport := newGatedTransport()
+
+	client := NewClient(transport.httpClient(), "")
+	defer client.Close()
+
+	var waitGroup sync.WaitGroup
+
+	errs := make(chan error, testWaiters)
+
+	for i := 0; i < testWaiters; i++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			bot, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+			if err == nil && bot.ClientID == "" {
+				err = errors.New("empty bot")
+			}
+
+			errs <- err
+		}()
+	}
+
+	waitForWaiters(t, client, testWaiters)
+	close(transport.release)
+	waitGroup.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf(queryBotErrorMessage, err)
+		}
+	}
+
+	if transport.count() != 1 {
+		t.Errorf("Unexpected requests for identical queries: %d", transport.count())
+	}
+
+	// Queries after the shared request completed make a new request.
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if transport.count() != 2 {
+		t.Errorf("Unexpected requests after shared request: %d", transport.count())
+	}
+}
+
+func TestClient_doSharedRequest_cancel(t *testing.T) {
+	transport := newGatedTransport()
+
+	client := NewClient(transport.httpClient(), "")
+	defer client.Close()
+
+	cancelledCtx, cancelCtx := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+
+	go func() {
+		_, err := client.QueryBotWithContext(cancelledCtx, testBotID, false)
+		cancelledErr <- err
+	}()
+
+	<-transport.started
+
+	remainingErr := make(chan error, 1)
+
+	go func() {
+		_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+		remainingErr <- err
+	}()
+
+	waitForWaiters(t, client, 2)
+
+	// Cancelling one waiter does not cancel the request shared with another.
+	cancelCtx()
+
+	err := <-cancelledErr
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error for cancelled waiter: %v", err)
+	}
+
+	close(transport.release)
+
+	err = <-remainingErr
+	if err != nil {
+		t.Errorf(queryBotErrorMessage, err)
+	}
+
+	if transport.count() != 1 {
+		t.Errorf("Unexpected requests: %d", transport.count())
+	}
+}
+
+func TestClient_doSharedRequest_abandon(t *testing.T) {
+	transport := newGatedTransport()
+
+	client := NewClient(transport.httpClient(), "")
+	defer client.Close()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	queryErr := make(chan error, 1)
+
+	go func() {
+		_, err := client.QueryBotWithContext(ctx, testBotID, false)
+		queryErr <- err
+	}()
+
+	<-transport.started
+	cancelCtx()
+
+	err := <-queryErr
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error for cancelled query: %v", err)
+	}
+
+	client.flights.mutex.Lock()
+	flights := len(client.flights.flights)
+	client.flights.mutex.Unlock()
+
+	if flights != 0 {
+		t.Errorf("Abandoned request still shared: %d", flights)
+	}
+}
+
+func TestFlightKey(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, testBaseURL, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error creating request: %s", err)
+	}
+
+	unauthenticated := flightKey(req)
+
+	req.Header.Set("Authorization", testBotToken)
+
+	if flightKey(req) == unauthenticated {
+		t.Errorf("Authenticated and unauthenticated requests share a key")
+	}
+}
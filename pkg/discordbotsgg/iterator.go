@@ -0,0 +1,114 @@
+package discordbotsgg
+
+import (
+	"context"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// BotsIterator iterates over every bot matching a query, fetching each page
+// of results as needed under the *Client query rate limit. A *BotsIterator is
+// not safe for concurrent use.
+type BotsIterator struct {
+	// MaxItems is the maximum number of bots to iterate over. Zero iterates
+	// over every bot matching the query. It should be set before the first
+	// call to Next.
+	MaxItems int
+
+	ctx             context.Context
+	client          *Client
+	queryParameters api.QueryParameters
+	opts            []CallOption
+	page            *api.Page
+	index           int
+	count           int
+	bot             *api.Bot
+	err             error
+	done            bool
+}
+
+// BotsIterator returns a new *BotsIterator over the bots matching the given
+// queryParameters, starting at queryParameters.Page. The queryParameters are
+// copied and not modified.
+func (client *Client) BotsIterator(ctx context.Context, queryParameters *api.QueryParameters, opts ...CallOption) *BotsIterator {
+	iterator := &BotsIterator{
+		ctx:    ctx,
+		client: client,
+		opts:   opts,
+	}
+
+	if queryParameters != nil {
+		iterator.queryParameters = *queryParameters
+	}
+
+	return iterator
+}
+
+// Next advances the *BotsIterator to the next bot, which is then available
+// from the Bot method. It returns false when there are no more bots or an
+// error occurred, which is then available from the Err method.
+func (iterator *BotsIterator) Next() bool {
+	iterator.bot = nil
+
+	if iterator.done || (iterator.MaxItems > 0 && iterator.count >= iterator.MaxItems) {
+		iterator.done = true
+		return false
+	}
+
+	if iterator.page == nil || iterator.index >= len(iterator.page.Bots) {
+		if iterator.page != nil && lastPage(iterator.page) {
+			iterator.done = true
+			return false
+		}
+
+		if !iterator.fetchPage() {
+			return false
+		}
+	}
+
+	iterator.bot = iterator.page.Bots[iterator.index]
+	iterator.index++
+	iterator.count++
+
+	return true
+}
+
+// Bot returns the current bot.
+func (iterator *BotsIterator) Bot() *api.Bot {
+	return iterator.bot
+}
+
+// Err returns the error, if any, which stopped the *BotsIterator.
+func (iterator *BotsIterator) Err() error {
+	return iterator.err
+}
+
+func (iterator *BotsIterator) fetchPage() bool {
+	if iterator.page != nil {
+		iterator.queryParameters.Page++
+	}
+
+	page, err := iterator.client.QueryBotsWithContext(iterator.ctx, &iterator.queryParameters, iterator.opts...)
+	if err != nil {
+		iterator.err = err
+		iterator.done = true
+
+		return false
+	}
+
+	iterator.page = page
+	iterator.index = 0
+
+	if len(page.Bots) == 0 {
+		iterator.done = true
+		return false
+	}
+
+	return true
+}
+
+// lastPage returns whether there are no more results after the given
+// *api.Page.
+func lastPage(page *api.Page) bool {
+	return len(page.Bots) == 0 || page.Page*page.Limit+len(page.Bots) >= page.Count
+}
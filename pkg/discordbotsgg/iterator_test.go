@@ -0,0 +1,155 @@
+package discordbotsgg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const (
+	testPagedCount = 5
+	testPagedLimit = 2
+)
+
+// newPagedHTTPClient returns an *http.Client serving testPagedCount bots in
+// pages of testPagedLimit, and a pointer to the number of requests served.
+func newPagedHTTPClient() (*http.Client, *int) {
+	requests := 0
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requests++
+
+		pageNumber, _ := strconv.Atoi(req.URL.Query().Get("page"))
+
+		page := &api.Page{
+			Count: testPagedCount,
+			Limit: testPagedLimit,
+			Page:  pageNumber,
+		}
+
+		for i := pageNumber * testPagedLimit; i < testPagedCount && len(page.Bots) < testPagedLimit; i++ {
+			page.Bots = append(page.Bots, &api.Bot{Username: fmt.Sprintf("Test Bot %d", i+1)})
+		}
+
+		respBody, err := json.Marshal(page)
+		if err != nil {
+			return nil, err
+		}
+
+		return newTestResponse(req, http.StatusOK, nil, string(respBody)), nil
+	})
+
+	return httpClient, &requests
+}
+
+func TestClient_BotsIterator(t *testing.T) {
+	httpClient, requests := newPagedHTTPClient()
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	queryParameters := &api.QueryParameters{Limit: testPagedLimit}
+	iterator := client.BotsIterator(context.Background(), queryParameters)
+
+	count := 0
+
+	for iterator.Next() {
+		count++
+
+		expected := fmt.Sprintf("Test Bot %d", count)
+
+		if iterator.Bot().Username != expected {
+			t.Errorf("Unexpected bot. Got: %s. Expected: %s.", iterator.Bot(), expected)
+		}
+	}
+
+	if iterator.Err() != nil {
+		t.Fatalf("Unexpected iterator error: %s", iterator.Err())
+	}
+
+	if count != testPagedCount || *requests != 3 {
+		t.Errorf("Unexpected bots and requests: %d %d", count, *requests)
+	}
+
+	if queryParameters.Page != 0 {
+		t.Errorf("Unexpected modification of query parameters: %+v", queryParameters)
+	}
+
+	if iterator.Next() || iterator.Bot() != nil {
+		t.Errorf("Unexpected bot after iterator finished")
+	}
+}
+
+func TestBotsIterator_MaxItems(t *testing.T) {
+	httpClient, requests := newPagedHTTPClient()
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	iterator := client.BotsIterator(context.Background(), &api.QueryParameters{Limit: testPagedLimit})
+	iterator.MaxItems = 3
+
+	count := 0
+
+	for iterator.Next() {
+		count++
+	}
+
+	if count != iterator.MaxItems || *requests != 2 {
+		t.Errorf("Unexpected bots and requests: %d %d", count, *requests)
+	}
+}
+
+func TestBotsIterator_Err(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	iterator := client.BotsIterator(context.Background(), &api.QueryParameters{Unverified: true})
+
+	if iterator.Next() {
+		t.Fatalf("Unexpected bot from failed query")
+	}
+
+	if !errors.Is(iterator.Err(), ErrTokenRequired) {
+		t.Errorf("Unexpected iterator error: %v", iterator.Err())
+	}
+
+	iterator = client.BotsIterator(context.Background(), nil)
+
+	count := 0
+
+	for iterator.Next() {
+		count++
+	}
+
+	if iterator.Err() != nil || count != 2 {
+		t.Errorf("Unexpected iteration over mock bots: %d %v", count, iterator.Err())
+	}
+}
+
+func ExampleClient_BotsIterator() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	iterator := client.BotsIterator(context.Background(), &api.QueryParameters{Lib: "discordgo"})
+
+	for iterator.Next() {
+		fmt.Printf("Bot: %s\n", iterator.Bot())
+	}
+
+	if iterator.Err() != nil {
+		fmt.Printf("Error: %s\n", iterator.Err())
+	}
+	// Output:
+	// Bot: Test Bot 1
+	// Bot: Test Bot 2
+}
@@ -0,0 +1,155 @@
+package discordbotsgg
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is an interface to abstract rate limiter implementations.
+type Limiter interface {
+	// Wait blocks until a request is permitted or the provided context is
+	// done, in which case the context's error is returned.
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a Limiter implementing the token bucket algorithm. The
+// bucket starts full, permitting bursts of up to its capacity, and refills at
+// a constant rate so that no more than capacity requests are permitted per
+// timeframe on average.
+type TokenBucket struct {
+	mutex     sync.Mutex
+	capacity  float64
+	tokens    float64
+	timeframe time.Duration
+	interval  time.Duration
+	last      time.Time
+	now       func() time.Time
+	sleep     func(ctx context.Context, duration time.Duration) error
+}
+
+// NewTokenBucket returns a new *TokenBucket permitting up to capacity requests
+// per the given timeframe.
+func NewTokenBucket(capacity int, timeframe time.Duration) *TokenBucket {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &TokenBucket{
+		capacity:  float64(capacity),
+		tokens:    float64(capacity),
+		timeframe: timeframe,
+		interval:  timeframe / time.Duration(capacity),
+		last:      time.Now(),
+		now:       time.Now,
+		sleep:     sleepWithContext,
+	}
+}
+
+// Wait takes a token from the bucket, blocking until one is available or
+// until the provided context is done.
+func (bucket *TokenBucket) Wait(ctx context.Context) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	bucket.mutex.Lock()
+
+	now := bucket.refill()
+	bucket.tokens--
+
+	if bucket.tokens >= 0 && !bucket.last.After(now) {
+		bucket.mutex.Unlock()
+		return nil
+	}
+
+	// The token has been reserved, so wait for it to be refilled. Refilling
+	// is paused until bucket.last if it is in the future.
+	wait := nonNegative(time.Duration(-bucket.tokens * float64(bucket.interval)))
+	wait += nonNegative(bucket.last.Sub(now))
+
+	bucket.mutex.Unlock()
+
+	err = bucket.sleep(ctx, wait)
+	if err != nil {
+		bucket.mutex.Lock()
+		bucket.refill()
+		bucket.tokens++
+		bucket.mutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// Adjust satisfies the AdaptiveLimiter interface. The bucket capacity is
+// updated to the reported limit, no more tokens than the reported remaining
+// requests are kept, and refilling is paused until the reported reset time
+// when no requests remain.
+func (bucket *TokenBucket) Adjust(rateLimit *RateLimit) {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	bucket.refill()
+
+	if rateLimit.Limit > 0 && float64(rateLimit.Limit) != bucket.capacity {
+		bucket.capacity = float64(rateLimit.Limit)
+		bucket.interval = bucket.timeframe / time.Duration(rateLimit.Limit)
+	}
+
+	if remaining := float64(rateLimit.Remaining); bucket.tokens > remaining {
+		bucket.tokens = remaining
+	}
+
+	if rateLimit.Remaining <= 0 && rateLimit.Reset.After(bucket.last) {
+		bucket.last = rateLimit.Reset
+	}
+}
+
+// refill must be called with the mutex held. It returns the current time.
+func (bucket *TokenBucket) refill() time.Time {
+	now := bucket.now()
+	elapsed := now.Sub(bucket.last)
+
+	if elapsed <= 0 {
+		return now
+	}
+
+	bucket.last = now
+
+	if bucket.interval <= 0 {
+		return now
+	}
+
+	bucket.tokens += float64(elapsed) / float64(bucket.interval)
+
+	if bucket.tokens > bucket.capacity {
+		bucket.tokens = bucket.capacity
+	}
+
+	return now
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type stopper interface {
+	Stop()
+}
+
+func stopLimiter(limiter Limiter) {
+	if limiterStopper, ok := limiter.(stopper); ok {
+		limiterStopper.Stop()
+	}
+}
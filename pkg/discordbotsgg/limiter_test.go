@@ -0,0 +1,176 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const (
+	testBucketCapacity  = 10
+	testBucketTimeframe = 5 * time.Second
+)
+
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Sleep(ctx context.Context, duration time.Duration) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	clock.slept = append(clock.slept, duration)
+	clock.now = clock.now.Add(duration)
+
+	return nil
+}
+
+func newTestTokenBucket() (*TokenBucket, *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+
+	bucket := NewTokenBucket(testBucketCapacity, testBucketTimeframe)
+	bucket.last = clock.now
+	bucket.now = clock.Now
+	bucket.sleep = clock.Sleep
+
+	return bucket, clock
+}
+
+func TestNewTokenBucket(t *testing.T) {
+	bucket := NewTokenBucket(0, testBucketTimeframe)
+
+	if bucket.capacity != 1 {
+		t.Errorf("Unexpected capacity: %f", bucket.capacity)
+	}
+
+	err := bucket.Wait(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error waiting on limiter: %s", err)
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket, clock := newTestTokenBucket()
+
+	for i := 0; i < testBucketCapacity; i++ {
+		err := bucket.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error waiting on limiter: %s", err)
+		}
+	}
+
+	if len(clock.slept) != 0 {
+		t.Fatalf("Unexpected wait during burst: %v", clock.slept)
+	}
+
+	err := bucket.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error waiting on limiter: %s", err)
+	}
+
+	expected := testBucketTimeframe / testBucketCapacity
+
+	if len(clock.slept) != 1 || clock.slept[0] != expected {
+		t.Fatalf("Unexpected waits after burst. Got: %v. Expected: [%s]", clock.slept, expected)
+	}
+
+	clock.now = clock.now.Add(testBucketTimeframe)
+
+	for i := 0; i < testBucketCapacity; i++ {
+		err = bucket.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error waiting on limiter: %s", err)
+		}
+	}
+
+	if len(clock.slept) != 1 {
+		t.Errorf("Unexpected wait after refill: %v", clock.slept)
+	}
+}
+
+func TestTokenBucket_Wait_canceled(t *testing.T) {
+	bucket, _ := newTestTokenBucket()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	err := bucket.Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error waiting on limiter with canceled context: %v", err)
+	}
+
+	if bucket.tokens != testBucketCapacity {
+		t.Errorf("Unexpected tokens taken by canceled wait: %f", bucket.tokens)
+	}
+
+	bucket.tokens = 0
+	bucket.sleep = func(context.Context, time.Duration) error {
+		return context.DeadlineExceeded
+	}
+
+	err = bucket.Wait(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error waiting on limiter with expired context: %v", err)
+	}
+
+	if bucket.tokens != 0 {
+		t.Errorf("Unexpected tokens after expired wait: %f", bucket.tokens)
+	}
+}
+
+func TestSleepWithContext(t *testing.T) {
+	err := sleepWithContext(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Errorf("Unexpected error sleeping: %s", err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	err = sleepWithContext(ctx, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error sleeping with canceled context: %v", err)
+	}
+}
+
+func TestTokenBucket_Adjust(t *testing.T) {
+	const (
+		reportedLimit     = 5
+		reportedRemaining = 2
+	)
+
+	bucket, clock := newTestTokenBucket()
+
+	bucket.Adjust(&RateLimit{Limit: reportedLimit, Remaining: reportedRemaining, Reset: clock.now})
+
+	if bucket.capacity != reportedLimit || bucket.interval != testBucketTimeframe/reportedLimit {
+		t.Errorf("Unexpected capacity and interval: %f %s", bucket.capacity, bucket.interval)
+	}
+
+	if bucket.tokens != reportedRemaining {
+		t.Errorf("Unexpected tokens: %f", bucket.tokens)
+	}
+
+	reset := clock.now.Add(testBucketTimeframe)
+
+	bucket.Adjust(&RateLimit{Remaining: 0, Reset: reset})
+
+	err := bucket.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error waiting on limiter: %s", err)
+	}
+
+	expected := testBucketTimeframe + testBucketTimeframe/reportedLimit
+
+	if len(clock.slept) != 1 || clock.slept[0] != expected {
+		t.Errorf("Unexpected waits after reset. Got: %v. Expected: [%s]", clock.slept, expected)
+	}
+}
@@ -0,0 +1,177 @@
+package discordbotsgg
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	endpointBot     = "bot"
+	endpointBots    = "bots"
+	endpointStats   = "stats"
+	endpointUnknown = "unknown"
+
+	redactedTokenSuffix = 4
+	redactedToken       = "[REDACTED]"
+
+	requestLogMessage = "discordbotsgg request"
+)
+
+// RequestEvent describes an attempt of a request to the API.
+type RequestEvent struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Endpoint is the API endpoint requested: "bot", "bots" or "stats".
+	Endpoint string
+
+	// URL is the URL of the request.
+	URL string
+
+	// StatusCode is the status code of the response, or zero if no response
+	// was received.
+	StatusCode int
+
+	// Duration is the time taken by the attempt, excluding LimiterWait.
+	Duration time.Duration
+
+	// LimiterWait is the time spent waiting on the rate limiter and any
+	// global rate limit before the attempt.
+	LimiterWait time.Duration
+
+	// Attempt is the number of the attempt, starting from 1.
+	Attempt int
+
+	// Token is the redacted API token sent with the request, if any.
+	Token string
+
+	// Err is the error of the attempt, if any.
+	Err error
+}
+
+// Logger receives a *RequestEvent for every attempt of a request to the
+// API. Implementations must be safe for concurrent use.
+type Logger interface {
+	LogRequest(event *RequestEvent)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) LogRequest(*RequestEvent) {}
+
+// KeyValueLogger is a Logger logging each *RequestEvent with a function
+// taking a message and alternating keys and values, such as slog.Info or the
+// methods of a logr.Logger.
+type KeyValueLogger func(msg string, keysAndValues ...interface{})
+
+// LogRequest satisfies the Logger interface.
+func (logFunc KeyValueLogger) LogRequest(event *RequestEvent) {
+	keysAndValues := []interface{}{
+		"method", event.Method,
+		"endpoint", event.Endpoint,
+		"url", event.URL,
+		"status", event.StatusCode,
+		"duration", event.Duration,
+		"limiterWait", event.LimiterWait,
+		"attempt", event.Attempt,
+	}
+
+	if event.Token != "" {
+		keysAndValues = append(keysAndValues, "token", event.Token)
+	}
+
+	if event.Err != nil {
+		keysAndValues = append(keysAndValues, "error", event.Err)
+	}
+
+	logFunc(requestLogMessage, keysAndValues...)
+}
+
+// WithLogger sets the Logger receiving an event for every attempt of a
+// request. The default discards every event.
+func WithLogger(logger Logger) Option {
+	return func(client *Client) {
+		client.Logger = logger
+	}
+}
+
+// logRequest logs the given *RequestEvent to the *Client Logger, which is
+// unset for a *Client not created by a constructor.
+func (client *Client) logRequest(event *RequestEvent) {
+	if client.Logger == nil {
+		return
+	}
+
+	client.Logger.LogRequest(event)
+}
+
+func newRequestEvent(req *http.Request, attempt int) *RequestEvent {
+	return &RequestEvent{
+		Method:   req.Method,
+		Endpoint: endpointName(req.URL),
+		URL:      req.URL.String(),
+		Attempt:  attempt,
+		Token:    redactToken(req.Header.Get("Authorization")),
+	}
+}
+
+// endpointName returns the name of the API endpoint of the given URL,
+// regardless of the base URL.
+func endpointName(u *url.URL) string {
+	path := strings.TrimSuffix(u.Path, "/")
+
+	switch {
+	case strings.HasSuffix(path, "/stats"):
+		return endpointStats
+	case strings.HasSuffix(path, "/bots"):
+		return endpointBots
+	case strings.Contains(path, "/bots/"):
+		return endpointBot
+	default:
+		return endpointUnknown
+	}
+}
+
+// parseEndpointName returns the name of the API endpoint of the given URL
+// string.
+func parseEndpointName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return endpointUnknown
+	}
+
+	return endpointName(u)
+}
+
+// redactToken returns the given token with all but its last few characters
+// redacted, so the token used can be told apart without being leaked.
+func redactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	if len(token) <= 2*redactedTokenSuffix {
+		return redactedToken
+	}
+
+	return redactedToken + token[len(token)-redactedTokenSuffix:]
+}
+
+// statusCode returns the status code of the response of an attempt, or zero
+// if no response was received.
+func statusCode(resp *response, err error) int {
+	if resp != nil {
+		return resp.statusCode
+	}
+
+	var apiErr *APIError
+
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
@@ -0,0 +1,148 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const testLongBotToken = "abcdefghijklmnop"
+
+type testLogger struct {
+	mutex  sync.Mutex
+	events []*RequestEvent
+}
+
+func (logger *testLogger) LogRequest(event *RequestEvent) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	logger.events = append(logger.events, event)
+}
+
+func TestClient_Logger(t *testing.T) {
+	attempts := 0
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+
+		if attempts < testMaxAttempts {
+			return newTestResponse(req, http.StatusServiceUnavailable, nil, ""), nil
+		}
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	logger := &testLogger{}
+
+	client := NewClientWithOptions(
+		WithHTTPClient(httpClient),
+		WithBotToken(testLongBotToken),
+		WithRetryPolicy(newTestRetryPolicy()),
+		WithLogger(logger),
+	)
+	defer client.Close()
+
+	_, err := client.UpdateWithContext(context.Background(), testBotID, &api.StatsUpdate{Stats: &api.Stats{}})
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	if len(logger.events) != testMaxAttempts {
+		t.Fatalf("Unexpected events. Got: %d. Expected: %d.", len(logger.events), testMaxAttempts)
+	}
+
+	for i, event := range logger.events {
+		if event.Method != http.MethodPost || event.Endpoint != endpointStats || event.Attempt != i+1 {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+
+		if strings.Contains(event.Token, testLongBotToken[:8]) || !strings.HasSuffix(event.Token, "mnop") {
+			t.Errorf("Unexpected redacted token: %s", event.Token)
+		}
+	}
+
+	first, last := logger.events[0], logger.events[testMaxAttempts-1]
+
+	if first.StatusCode != http.StatusServiceUnavailable || !errors.Is(first.Err, ErrServer) {
+		t.Errorf("Unexpected failed attempt event: %+v", first)
+	}
+
+	if last.StatusCode != http.StatusOK || last.Err != nil {
+		t.Errorf("Unexpected successful attempt event: %+v", last)
+	}
+}
+
+func TestKeyValueLogger(t *testing.T) {
+	var (
+		message string
+		fields  = make(map[string]interface{})
+	)
+
+	logger := KeyValueLogger(func(msg string, keysAndValues ...interface{}) {
+		message = msg
+
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+		}
+	})
+
+	logger.LogRequest(&RequestEvent{
+		Method:     http.MethodGet,
+		Endpoint:   endpointBot,
+		StatusCode: http.StatusNotFound,
+		Attempt:    1,
+		Token:      redactToken(testLongBotToken),
+		Err:        ErrNotFound,
+	})
+
+	if message != requestLogMessage {
+		t.Errorf("Unexpected message: %s", message)
+	}
+
+	if fields["endpoint"] != endpointBot || fields["status"] != http.StatusNotFound || fields["error"] != ErrNotFound {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+
+	if _, ok := fields["token"]; !ok {
+		t.Errorf("Missing token field: %v", fields)
+	}
+}
+
+func TestEndpointName(t *testing.T) {
+	tests := map[string]string{
+		api.NewEndpoints(testBaseURL).Bot(testBotID, true): endpointBot,
+		api.NewEndpoints("").Bots(&api.QueryParameters{}):  endpointBots,
+		api.NewEndpoints(testBaseURL).Stats(testBotID):     endpointStats,
+		testBaseURL: endpointUnknown,
+	}
+
+	for rawURL, expected := range tests {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing URL: %s", err)
+		}
+
+		if name := endpointName(u); name != expected {
+			t.Errorf("Unexpected endpoint for %s. Got: %s. Expected: %s.", rawURL, name, expected)
+		}
+	}
+}
+
+func TestRedactToken(t *testing.T) {
+	if redactToken("") != "" {
+		t.Errorf("Unexpected redacted empty token")
+	}
+
+	if redactToken(testBotToken) == testBotToken || strings.Contains(redactToken("short"), "short") {
+		t.Errorf("Token not redacted")
+	}
+}
@@ -0,0 +1,306 @@
+package discordbotsgg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+	metricsNamespace   = "discordbotsgg_"
+
+	statusLabelError = "error"
+)
+
+// defaultBuckets returns the upper bounds in seconds of the buckets of the
+// duration histograms, matching the Prometheus client defaults.
+func defaultBuckets() []float64 {
+	return []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+}
+
+// Metrics records metrics of the requests of every *Client configured with
+// WithMetrics, and serves them in the Prometheus text exposition format. A
+// *Metrics is safe for concurrent use.
+type Metrics struct {
+	mutex           sync.Mutex
+	requests        *counterVec
+	retries         *counterVec
+	cacheHits       *counterVec
+	cacheMisses     *counterVec
+	unknownFields   *counterVec
+	requestDuration *histogramVec
+	limiterWait     *histogramVec
+}
+
+// NewMetrics returns a new *Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests: newCounterVec(
+			"requests_total",
+			"Request attempts to the discord.bots.gg API by endpoint, method and status code.",
+		),
+		retries: newCounterVec(
+			"retries_total",
+			"Retried request attempts to the discord.bots.gg API by endpoint and method.",
+		),
+		cacheHits: newCounterVec(
+			"cache_hits_total",
+			"Queries served from the response cache, including responses revalidated with a 304 Not Modified response.",
+		),
+		cacheMisses: newCounterVec(
+			"cache_misses_total",
+			"Queries not served from the response cache.",
+		),
+		unknownFields: newCounterVec(
+			"unknown_fields_total",
+			"Fields of API responses not modelled by the api package by endpoint and field, counted with WithStrictDecoding.",
+		),
+		requestDuration: newHistogramVec(
+			"request_duration_seconds",
+			"Duration of request attempts to the discord.bots.gg API by endpoint and method.",
+		),
+		limiterWait: newHistogramVec(
+			"limiter_wait_seconds",
+			"Time spent waiting on rate limiters before request attempts by endpoint and method.",
+		),
+	}
+}
+
+// WithMetrics sets the *Metrics recording metrics of the requests of the
+// *Client. A *Metrics may be shared by several clients.
+func WithMetrics(metrics *Metrics) Option {
+	return func(client *Client) {
+		client.metrics = metrics
+	}
+}
+
+// ServeHTTP satisfies the http.Handler interface, serving the metrics in the
+// Prometheus text exposition format.
+func (metrics *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	buffer := &bytes.Buffer{}
+
+	metrics.mutex.Lock()
+
+	for _, counter := range []*counterVec{metrics.requests, metrics.retries, metrics.cacheHits, metrics.cacheMisses, metrics.unknownFields} {
+		counter.write(buffer)
+	}
+
+	for _, histogram := range []*histogramVec{metrics.requestDuration, metrics.limiterWait} {
+		histogram.write(buffer)
+	}
+
+	metrics.mutex.Unlock()
+
+	w.Header().Set("Content-Type", metricsContentType)
+	_, _ = w.Write(buffer.Bytes())
+}
+
+// observeRequest records the given *RequestEvent of a sent attempt. It is a
+// no-op for a nil *Metrics.
+func (metrics *Metrics) observeRequest(event *RequestEvent) {
+	if metrics == nil {
+		return
+	}
+
+	status := statusLabelError
+
+	if event.StatusCode != 0 {
+		status = strconv.Itoa(event.StatusCode)
+	}
+
+	labels := formatLabels("endpoint", event.Endpoint, "method", event.Method)
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.limiterWait.observe(labels, event.LimiterWait.Seconds())
+	metrics.requests.inc(formatLabels("endpoint", event.Endpoint, "method", event.Method, "status", status))
+	metrics.requestDuration.observe(labels, event.Duration.Seconds())
+
+	if event.Attempt > 1 {
+		metrics.retries.inc(labels)
+	}
+}
+
+// observeLimiterWait records the limiter wait of the given *RequestEvent of
+// an attempt which was not sent. It is a no-op for a nil *Metrics.
+func (metrics *Metrics) observeLimiterWait(event *RequestEvent) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.limiterWait.observe(formatLabels("endpoint", event.Endpoint, "method", event.Method), event.LimiterWait.Seconds())
+}
+
+// observeCache records a query served from the response cache, or not. It is
+// a no-op for a nil *Metrics.
+func (metrics *Metrics) observeCache(endpoint string, hit bool) {
+	if metrics == nil {
+		return
+	}
+
+	labels := formatLabels("endpoint", endpoint)
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if hit {
+		metrics.cacheHits.inc(labels)
+		return
+	}
+
+	metrics.cacheMisses.inc(labels)
+}
+
+// observeDrift records the unknown fields of the given *SchemaDrift. It is a
+// no-op for a nil *Metrics.
+func (metrics *Metrics) observeDrift(drift *SchemaDrift) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	for _, field := range drift.UnknownFields {
+		metrics.unknownFields.inc(formatLabels("endpoint", drift.Endpoint, "field", field))
+	}
+}
+
+// counterVec is a counter partitioned by labels. It is not safe for
+// concurrent use.
+type counterVec struct {
+	name   string
+	help   string
+	values map[string]float64
+}
+
+func newCounterVec(name, help string) *counterVec {
+	return &counterVec{
+		name:   metricsNamespace + name,
+		help:   help,
+		values: make(map[string]float64),
+	}
+}
+
+func (counter *counterVec) inc(labels string) {
+	counter.values[labels]++
+}
+
+func (counter *counterVec) write(buffer *bytes.Buffer) {
+	writeHeader(buffer, counter.name, counter.help, "counter")
+
+	for _, labels := range sortedLabels(counter.values) {
+		fmt.Fprintf(buffer, "%s{%s} %s\n", counter.name, labels, formatValue(counter.values[labels]))
+	}
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// histogramVec is a histogram partitioned by labels. It is not safe for
+// concurrent use.
+type histogramVec struct {
+	name    string
+	help    string
+	buckets []float64
+	values  map[string]*histogram
+}
+
+func newHistogramVec(name, help string) *histogramVec {
+	return &histogramVec{
+		name:    metricsNamespace + name,
+		help:    help,
+		buckets: defaultBuckets(),
+		values:  make(map[string]*histogram),
+	}
+}
+
+func (histogramVec *histogramVec) observe(labels string, value float64) {
+	values, ok := histogramVec.values[labels]
+	if !ok {
+		values = &histogram{counts: make([]uint64, len(histogramVec.buckets))}
+		histogramVec.values[labels] = values
+	}
+
+	for i, upperBound := range histogramVec.buckets {
+		if value <= upperBound {
+			values.counts[i]++
+		}
+	}
+
+	values.count++
+	values.sum += value
+}
+
+func (histogramVec *histogramVec) write(buffer *bytes.Buffer) {
+	writeHeader(buffer, histogramVec.name, histogramVec.help, "histogram")
+
+	labelSets := make([]string, 0, len(histogramVec.values))
+
+	for labels := range histogramVec.values {
+		labelSets = append(labelSets, labels)
+	}
+
+	sort.Strings(labelSets)
+
+	for _, labels := range labelSets {
+		values := histogramVec.values[labels]
+
+		for i, upperBound := range histogramVec.buckets {
+			fmt.Fprintf(buffer, "%s_bucket{%s,le=%q} %d\n", histogramVec.name, labels, formatValue(upperBound), values.counts[i])
+		}
+
+		fmt.Fprintf(buffer, "%s_bucket{%s,le=\"+Inf\"} %d\n", histogramVec.name, labels, values.count)
+		fmt.Fprintf(buffer, "%s_sum{%s} %s\n", histogramVec.name, labels, formatValue(values.sum))
+		fmt.Fprintf(buffer, "%s_count{%s} %d\n", histogramVec.name, labels, values.count)
+	}
+}
+
+func writeHeader(buffer *bytes.Buffer, name, help, metricType string) {
+	fmt.Fprintf(buffer, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buffer, "# TYPE %s %s\n", name, metricType)
+}
+
+// formatLabels formats the given alternating label names and values as they
+// appear between the braces of a sample.
+func formatLabels(namesAndValues ...string) string {
+	labels := make([]string, 0, len(namesAndValues)/2)
+
+	for i := 0; i+1 < len(namesAndValues); i += 2 {
+		labels = append(labels, fmt.Sprintf("%s=\"%s\"", namesAndValues[i], escapeLabelValue(namesAndValues[i+1])))
+	}
+
+	return strings.Join(labels, ",")
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedLabels(values map[string]float64) []string {
+	labelSets := make([]string, 0, len(values))
+
+	for labels := range values {
+		labelSets = append(labelSets, labels)
+	}
+
+	sort.Strings(labelSets)
+
+	return labelSets
+}
@@ -0,0 +1,140 @@
+package discordbotsgg
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+func scrapeMetrics(t *testing.T, metrics *Metrics) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != metricsContentType {
+		t.Errorf("Unexpected content type: %s", contentType)
+	}
+
+	body, err := ioutil.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("Unexpected error reading metrics: %s", err)
+	}
+
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	attempts := 0
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPost {
+			attempts++
+
+			if attempts < testMaxAttempts {
+				return newTestResponse(req, http.StatusServiceUnavailable, nil, ""), nil
+			}
+		}
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	metrics := NewMetrics()
+
+	client := NewClientWithOptions(
+		WithHTTPClient(httpClient),
+		WithBotToken(testBotToken),
+		WithRetryPolicy(newTestRetryPolicy()),
+		WithCache(NewMemoryCache(0, 0), testCacheTTL, 0),
+		WithMetrics(metrics),
+	)
+	defer client.Close()
+
+	_, err := client.UpdateWithContext(context.Background(), testBotID, &api.StatsUpdate{Stats: &api.Stats{}})
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = client.QueryBotWithContext(context.Background(), testBotID, false)
+		if err != nil {
+			t.Fatalf(queryBotErrorMessage, err)
+		}
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+
+	expectedLines := []string{
+		"# TYPE discordbotsgg_requests_total counter",
+		`discordbotsgg_requests_total{endpoint="stats",method="POST",status="503"} 2`,
+		`discordbotsgg_requests_total{endpoint="stats",method="POST",status="200"} 1`,
+		`discordbotsgg_requests_total{endpoint="bot",method="GET",status="200"} 1`,
+		`discordbotsgg_retries_total{endpoint="stats",method="POST"} 2`,
+		`discordbotsgg_cache_hits_total{endpoint="bot"} 1`,
+		`discordbotsgg_cache_misses_total{endpoint="bot"} 1`,
+		"# TYPE discordbotsgg_request_duration_seconds histogram",
+		`discordbotsgg_request_duration_seconds_bucket{endpoint="stats",method="POST",le="+Inf"} 3`,
+		`discordbotsgg_request_duration_seconds_count{endpoint="bot",method="GET"} 1`,
+		`discordbotsgg_limiter_wait_seconds_count{endpoint="stats",method="POST"} 3`,
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(exposition, line+"\n") {
+			t.Errorf("Missing metrics line: %s\n%s", line, exposition)
+		}
+	}
+}
+
+func TestMetrics_observeLimiterWait(t *testing.T) {
+	metrics := NewMetrics()
+
+	client := NewClientWithOptions(WithHTTPClient(mock.NewHTTPClient()), WithMetrics(metrics))
+	client.Close()
+
+	client.UpdateLimiter = blockingLimiter{}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	_, err := client.UpdateWithContext(ctx, testBotID, &api.StatsUpdate{Stats: &api.Stats{}})
+	if err == nil {
+		t.Fatalf("Expected error waiting on limiter")
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+
+	if strings.Contains(exposition, "discordbotsgg_requests_total{") {
+		t.Errorf("Unexpected request recorded for attempt not sent:\n%s", exposition)
+	}
+
+	if !strings.Contains(exposition, `discordbotsgg_limiter_wait_seconds_count{endpoint="stats",method="POST"} 1`) {
+		t.Errorf("Missing limiter wait:\n%s", exposition)
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	labels := formatLabels("endpoint", `a"b\c`)
+
+	if labels != `endpoint="a\"b\\c"` {
+		t.Errorf("Unexpected labels: %s", labels)
+	}
+}
+
+func ExampleNewMetrics() {
+	metrics := NewMetrics()
+
+	client := NewClientWithOptions(
+		WithHTTPClient(mock.NewHTTPClient()), // Substitute a real *http.Client here.
+		WithMetrics(metrics),
+	)
+	defer client.Close()
+
+	// Serve the metrics to Prometheus.
+	http.Handle("/metrics", metrics)
+}
@@ -0,0 +1,47 @@
+package discordbotsgg
+
+import "net/http"
+
+// ErrNilResponse is returned when the Middleware or HTTPClient of a *Client
+// returns neither an *http.Response nor an error.
+const ErrNilResponse = constError("nil response without error")
+
+// RoundTripFunc sends an *http.Request and returns its *http.Response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the RoundTripFunc sending each attempt of a request, such
+// as to log requests, add headers, refresh credentials, record metrics or
+// inject faults. A Middleware may modify the *http.Request it is given, which
+// is a copy made for the attempt, and must return either a non-nil
+// *http.Response or an error.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware appends the given Middleware to the *Client Middleware.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(client *Client) {
+		client.Middleware = append(client.Middleware, middleware...)
+	}
+}
+
+// roundTrip sends the given *http.Request with the *Client HTTPClient through
+// its Middleware. The first Middleware is the outermost, seeing the request
+// first and the response last. A nil *http.Response without an error is
+// returned as ErrNilResponse.
+func (client *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(client.HTTPClient.Do)
+
+	for i := len(client.Middleware) - 1; i >= 0; i-- {
+		next = client.Middleware[i](next)
+	}
+
+	if len(client.Middleware) > 0 {
+		req = req.Clone(req.Context())
+	}
+
+	resp, err := next(req)
+	if resp == nil && err == nil {
+		return nil, ErrNilResponse
+	}
+
+	return resp, err
+}
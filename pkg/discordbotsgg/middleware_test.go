@@ -0,0 +1,146 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const testMiddlewareHeader = "X-Test-Middleware"
+
+func newTestMiddleware(name string, calls *[]string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name)
+			req.Header.Add(testMiddlewareHeader, name)
+
+			resp, err := next(req)
+
+			*calls = append(*calls, name)
+
+			return resp, err
+		}
+	}
+}
+
+func TestClient_Middleware(t *testing.T) {
+	var (
+		calls   []string
+		headers []string
+	)
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		headers = req.Header.Values(testMiddlewareHeader)
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	client := NewClientWithOptions(
+		WithHTTPClient(httpClient),
+		WithMiddleware(newTestMiddleware("outer", &calls)),
+		WithMiddleware(newTestMiddleware("inner", &calls)),
+	)
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	expected := []string{"outer", "inner", "inner", "outer"}
+
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected middleware calls. Got: %v. Expected: %v.", calls, expected)
+	}
+
+	if fmt.Sprint(headers) != fmt.Sprint(expected[:2]) {
+		t.Errorf("Unexpected middleware headers: %v", headers)
+	}
+}
+
+func TestClient_Middleware_retry(t *testing.T) {
+	attempts := 0
+
+	// Inject a fault into every attempt but the last, which must not see the
+	// headers added to earlier attempts.
+	faultInjector := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts++
+
+			if len(req.Header.Values(testMiddlewareHeader)) != 0 {
+				return nil, errors.New("header from an earlier attempt")
+			}
+
+			req.Header.Add(testMiddlewareHeader, "attempt")
+
+			if attempts < testMaxAttempts {
+				return newTestResponse(req, http.StatusServiceUnavailable, nil, ""), nil
+			}
+
+			return next(req)
+		}
+	}
+
+	client := NewClientWithOptions(
+		WithHTTPClient(mock.NewHTTPClient()),
+		WithRetryPolicy(newTestRetryPolicy()),
+		WithMiddleware(faultInjector),
+	)
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if attempts != testMaxAttempts {
+		t.Errorf("Unexpected attempts. Got: %d. Expected: %d.", attempts, testMaxAttempts)
+	}
+}
+
+func TestClient_Middleware_nilResponse(t *testing.T) {
+	nilResponse := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		}
+	}
+
+	client := NewClientWithOptions(WithHTTPClient(mock.NewHTTPClient()), WithMiddleware(nilResponse))
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if !errors.Is(err, ErrNilResponse) {
+		t.Errorf("Unexpected error from nil middleware response: %v", err)
+	}
+}
+
+func ExampleWithMiddleware() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	logger := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+
+			fmt.Printf("%s %s: %d\n", req.Method, req.URL.Path, resp.StatusCode)
+
+			return resp, nil
+		}
+	}
+
+	client := NewClientWithOptions(WithHTTPClient(httpClient), WithMiddleware(logger))
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), "12345", true)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	// Output: GET /api/v1/bots/12345: 200
+}
@@ -0,0 +1,72 @@
+package discordbotsgg
+
+import (
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// Option configures a *Client created with NewClientWithOptions.
+type Option func(client *Client)
+
+// WithHTTPClient sets the HTTPClient used to send requests. The default is an
+// empty *http.Client.
+func WithHTTPClient(httpClient HTTPClient) Option {
+	return func(client *Client) {
+		client.HTTPClient = httpClient
+	}
+}
+
+// WithBotToken sets the API token used to authenticate requests.
+func WithBotToken(botToken string) Option {
+	return func(client *Client) {
+		client.BotToken = botToken
+	}
+}
+
+// WithBaseURL sets the base URL of the API, such as a staging server, a
+// local stand-in or a proxy path. The default is api.DefaultBaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(client *Client) {
+		client.apiEndpoints = api.NewEndpoints(baseURL)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(client *Client) {
+		client.userAgent = userAgent
+	}
+}
+
+// WithQueryLimit sets the rate limit for requests to query bots to limit
+// requests per timeframe.
+func WithQueryLimit(limit int, timeframe time.Duration) Option {
+	return func(client *Client) {
+		client.QueryLimiter = NewTokenBucket(limit, timeframe)
+	}
+}
+
+// WithUpdateLimit sets the rate limit for requests to update bot stats to
+// limit requests per timeframe.
+func WithUpdateLimit(limit int, timeframe time.Duration) Option {
+	return func(client *Client) {
+		client.UpdateLimiter = NewTokenBucket(limit, timeframe)
+	}
+}
+
+// WithRequestTimeout sets the timeout for each attempt of a request,
+// including reading the response body. The default is no timeout beyond
+// that of the provided context.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		client.requestTimeout = timeout
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy used to retry failed requests.
+func WithRetryPolicy(retryPolicy *RetryPolicy) Option {
+	return func(client *Client) {
+		client.RetryPolicy = retryPolicy
+	}
+}
@@ -0,0 +1,141 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const (
+	testBaseURL        = "http://localhost:8080/proxy/"
+	testUserAgent      = "testUserAgent"
+	testRequestTimeout = 10 * time.Millisecond
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	client := NewClientWithOptions()
+	defer client.Close()
+
+	if client.HTTPClient == nil || client.QueryLimiter == nil || client.UpdateLimiter == nil {
+		t.Fatalf("Unexpected unset *Client defaults: %+v", client)
+	}
+
+	if client.userAgent != defaultUserAgent {
+		t.Errorf("Unexpected user agent: %s", client.userAgent)
+	}
+}
+
+func ExampleNewClientWithOptions() {
+	client := NewClientWithOptions(
+		WithHTTPClient(mock.NewHTTPClient()), // Substitute a real *http.Client here.
+		WithBotToken("apiToken"),
+		WithBaseURL("https://staging.example.com"),
+		WithUserAgent("myBot/1.0"),
+		WithRequestTimeout(10*time.Second),
+	)
+	defer client.Close()
+
+	bot, err := client.QueryBot("botID", true)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	fmt.Printf("Bot: %s\n", bot)
+	// Output: Bot: Test Bot 1
+}
+
+func TestWithBaseURL(t *testing.T) {
+	var requestURL, requestUserAgent string
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requestURL = req.URL.String()
+		requestUserAgent = req.UserAgent()
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	client := NewClientWithOptions(
+		WithHTTPClient(httpClient),
+		WithBaseURL(testBaseURL),
+		WithUserAgent(testUserAgent),
+	)
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, true)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	expected := api.NewEndpoints(testBaseURL).Bot(testBotID, true)
+
+	if requestURL != expected {
+		t.Errorf("Unexpected request URL. Got: %s. Expected: %s.", requestURL, expected)
+	}
+
+	if requestUserAgent != testUserAgent {
+		t.Errorf("Unexpected user agent. Got: %s. Expected: %s.", requestUserAgent, testUserAgent)
+	}
+}
+
+func TestWithQueryLimit(t *testing.T) {
+	client := NewClientWithOptions(
+		WithQueryLimit(1, time.Hour),
+		WithUpdateLimit(1, time.Hour),
+		WithHTTPClient(mock.NewHTTPClient()),
+	)
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, true)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), testRequestTimeout)
+	defer cancelCtx()
+
+	_, err = client.QueryBotWithContext(ctx, testBotID, true)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error exceeding query limit: %v", err)
+	}
+
+	bucket := client.UpdateLimiter.(*TokenBucket)
+
+	if bucket.capacity != 1 || bucket.interval != time.Hour {
+		t.Errorf("Unexpected update limiter: %f %s", bucket.capacity, bucket.interval)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	attempts := 0
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+
+		<-req.Context().Done()
+
+		return nil, req.Context().Err()
+	})
+
+	client := NewClientWithOptions(
+		WithHTTPClient(httpClient),
+		WithRequestTimeout(testRequestTimeout),
+		WithRetryPolicy(newTestRetryPolicy()),
+	)
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, true)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error exceeding request timeout: %v", err)
+	}
+
+	if attempts != testMaxAttempts {
+		t.Errorf("Unexpected attempts. Got: %d. Expected: %d.", attempts, testMaxAttempts)
+	}
+}
@@ -0,0 +1,264 @@
+package discordbotsgg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+const outboxFileMode = 0o600
+
+type outboxKey struct {
+	botID   string
+	shardID int
+}
+
+type outboxEntry struct {
+	BotID       string           `json:"botID"`
+	StatsUpdate *api.StatsUpdate `json:"statsUpdate"`
+	Enqueued    time.Time        `json:"enqueued"`
+}
+
+// Outbox persists pending stats updates to a local JSON file until they have
+// been posted, so they survive restarts. Pending updates are coalesced to the
+// latest update for each bot and shard. An *Outbox is safe for concurrent
+// use.
+type Outbox struct {
+	// ErrorHandler, if set, is called with every error flushing the *Outbox
+	// by Run. It should be set before calling Run.
+	ErrorHandler func(err error)
+
+	client  *Client
+	path    string
+	mutex   sync.Mutex
+	pending map[outboxKey]*outboxEntry
+}
+
+// OpenOutbox returns a new *Outbox persisting pending stats updates to the
+// file at the given path, loading any updates left pending by a previous
+// *Outbox. Loaded updates are posted by Flush or Run.
+func OpenOutbox(client *Client, path string) (*Outbox, error) {
+	outbox := &Outbox{
+		client:  client,
+		path:    path,
+		pending: make(map[outboxKey]*outboxEntry),
+	}
+
+	outboxBytes, err := ioutil.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return outbox, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*outboxEntry
+
+	err = json.Unmarshal(outboxBytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load outbox %s: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		outbox.coalesce(entry)
+	}
+
+	return outbox, nil
+}
+
+// Enqueue persists the given stats update for the given botID, replacing any
+// pending update for the same bot and shard.
+func (outbox *Outbox) Enqueue(botID string, statsUpdate *api.StatsUpdate) error {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+
+	outbox.coalesce(&outboxEntry{
+		BotID:       botID,
+		StatsUpdate: copyStatsUpdate(statsUpdate),
+		Enqueued:    time.Now(),
+	})
+
+	return outbox.persist()
+}
+
+// Pending returns the number of pending stats updates.
+func (outbox *Outbox) Pending() int {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+
+	return len(outbox.pending)
+}
+
+// Flush posts every pending stats update, removing those posted successfully
+// and those rejected by the API as a bad request or for an unknown bot. The
+// others remain pending to be retried, and the first error is returned.
+func (outbox *Outbox) Flush(ctx context.Context) error {
+	var firstErr error
+
+	for _, entry := range outbox.entries() {
+		_, err := outbox.client.UpdateWithContext(ctx, entry.BotID, entry.StatsUpdate)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("bot %s shard %d: %w", entry.BotID, entry.StatsUpdate.ShardID, err)
+		}
+
+		if err != nil && !errors.Is(err, ErrBadRequest) && !errors.Is(err, ErrNotFound) {
+			continue
+		}
+
+		persistErr := outbox.remove(entry)
+		if persistErr != nil && firstErr == nil {
+			firstErr = persistErr
+		}
+	}
+
+	return firstErr
+}
+
+// Run flushes the *Outbox immediately, replaying any updates left pending by
+// a previous *Outbox, and then every interval until the provided context is
+// done. It returns ErrInvalidInterval if the interval is not positive.
+func (outbox *Outbox) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return ErrInvalidInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if outbox.Pending() > 0 {
+			err := outbox.Flush(ctx)
+			if err != nil && outbox.ErrorHandler != nil {
+				outbox.ErrorHandler(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// entries returns the pending entries ordered by enqueue time.
+func (outbox *Outbox) entries() []*outboxEntry {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+
+	return outbox.sortedEntries()
+}
+
+// sortedEntries must be called with the mutex held.
+func (outbox *Outbox) sortedEntries() []*outboxEntry {
+	entries := make([]*outboxEntry, 0, len(outbox.pending))
+
+	for _, entry := range outbox.pending {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Enqueued.Before(entries[j].Enqueued)
+	})
+
+	return entries
+}
+
+// remove removes the given entry unless it has been replaced by a newer
+// update since it was posted.
+func (outbox *Outbox) remove(entry *outboxEntry) error {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+
+	key := entry.key()
+
+	if outbox.pending[key] != entry {
+		return nil
+	}
+
+	delete(outbox.pending, key)
+
+	return outbox.persist()
+}
+
+// coalesce must be called with the mutex held, or before the *Outbox is
+// shared.
+func (outbox *Outbox) coalesce(entry *outboxEntry) {
+	if entry.StatsUpdate == nil {
+		return
+	}
+
+	key := entry.key()
+
+	if existing, ok := outbox.pending[key]; ok && existing.Enqueued.After(entry.Enqueued) {
+		return
+	}
+
+	outbox.pending[key] = entry
+}
+
+// persist atomically writes the pending entries to the outbox file. It must
+// be called with the mutex held.
+func (outbox *Outbox) persist() error {
+	outboxBytes, err := json.Marshal(outbox.sortedEntries())
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(outbox.path, outboxBytes)
+}
+
+func (entry *outboxEntry) key() outboxKey {
+	return outboxKey{
+		botID:   entry.BotID,
+		shardID: entry.StatsUpdate.ShardID,
+	}
+}
+
+// writeFileAtomic writes data to a temporary file which then replaces the
+// file at the given path, so the file is never left partially written.
+func writeFileAtomic(path string, data []byte) (err error) {
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tempFile.Close()
+			_ = os.Remove(tempFile.Name())
+		}
+	}()
+
+	_, err = tempFile.Write(data)
+	if err != nil {
+		return err
+	}
+
+	err = tempFile.Sync()
+	if err != nil {
+		return err
+	}
+
+	err = tempFile.Chmod(outboxFileMode)
+	if err != nil {
+		return err
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
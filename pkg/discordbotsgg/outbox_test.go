@@ -0,0 +1,214 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const (
+	testOutboxFile     = "outbox.json"
+	testOutboxInterval = 5 * time.Millisecond
+)
+
+func newTestStatsUpdate(guildCount, shardID int) *api.StatsUpdate {
+	return &api.StatsUpdate{
+		Stats:   &api.Stats{GuildCount: guildCount},
+		ShardID: shardID,
+	}
+}
+
+func TestOpenOutbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), testOutboxFile)
+
+	outbox, err := OpenOutbox(nil, path)
+	if err != nil {
+		t.Fatalf("Unexpected error opening new outbox: %s", err)
+	}
+
+	err = outbox.Enqueue(testBotID, newTestStatsUpdate(1, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error enqueueing stats update: %s", err)
+	}
+
+	err = outbox.Enqueue(testBotID, newTestStatsUpdate(2, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error enqueueing stats update: %s", err)
+	}
+
+	err = outbox.Enqueue(testBotID, newTestStatsUpdate(3, 1))
+	if err != nil {
+		t.Fatalf("Unexpected error enqueueing stats update: %s", err)
+	}
+
+	reopened, err := OpenOutbox(nil, path)
+	if err != nil {
+		t.Fatalf("Unexpected error reopening outbox: %s", err)
+	}
+
+	if reopened.Pending() != 2 {
+		t.Fatalf("Unexpected pending updates after reopening: %d", reopened.Pending())
+	}
+
+	entry := reopened.pending[outboxKey{botID: testBotID}]
+	if entry == nil || entry.StatsUpdate.GuildCount != 2 {
+		t.Errorf("Unexpected coalesced update: %+v", entry)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != outboxFileMode {
+		t.Errorf("Unexpected outbox file: %v %v", info, err)
+	}
+
+	err = ioutil.WriteFile(path, []byte("{"), outboxFileMode)
+	if err != nil {
+		t.Fatalf("Unexpected error corrupting outbox: %s", err)
+	}
+
+	_, err = OpenOutbox(nil, path)
+	if err == nil {
+		t.Errorf("Expected error opening corrupt outbox")
+	}
+
+	_, err = OpenOutbox(nil, t.TempDir())
+	if err == nil {
+		t.Errorf("Expected error opening directory as outbox")
+	}
+}
+
+func TestOutbox_Flush(t *testing.T) {
+	var (
+		mutex      sync.Mutex
+		statusCode = http.StatusServiceUnavailable
+	)
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if statusCode != http.StatusOK {
+			return newTestResponse(req, statusCode, nil, ""), nil
+		}
+
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	path := filepath.Join(t.TempDir(), testOutboxFile)
+
+	outbox, err := OpenOutbox(client, path)
+	if err != nil {
+		t.Fatalf("Unexpected error opening outbox: %s", err)
+	}
+
+	err = outbox.Enqueue(testBotID, newTestStatsUpdate(testGuildCount, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error enqueueing stats update: %s", err)
+	}
+
+	err = outbox.Flush(context.Background())
+	if !errors.Is(err, ErrServer) || outbox.Pending() != 1 {
+		t.Fatalf("Unexpected flush result during outage: %v %d", err, outbox.Pending())
+	}
+
+	mutex.Lock()
+	statusCode = http.StatusOK
+	mutex.Unlock()
+
+	// Simulate a restart by replaying the persisted outbox.
+	outbox, err = OpenOutbox(client, path)
+	if err != nil {
+		t.Fatalf("Unexpected error reopening outbox: %s", err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 4*testOutboxInterval)
+	defer cancelCtx()
+
+	err = outbox.Run(ctx, testOutboxInterval)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error from Run: %v", err)
+	}
+
+	if outbox.Pending() != 0 {
+		t.Errorf("Unexpected pending updates after replay: %d", outbox.Pending())
+	}
+
+	mutex.Lock()
+	statusCode = http.StatusBadRequest
+	mutex.Unlock()
+
+	err = outbox.Enqueue(testBotID, newTestStatsUpdate(testGuildCount, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error enqueueing stats update: %s", err)
+	}
+
+	err = outbox.Flush(context.Background())
+	if !errors.Is(err, ErrBadRequest) || outbox.Pending() != 0 {
+		t.Errorf("Unexpected flush result for rejected update: %v %d", err, outbox.Pending())
+	}
+}
+
+func TestOutbox_Run_invalidInterval(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), testBotToken)
+	defer client.Close()
+
+	outbox, err := OpenOutbox(client, filepath.Join(t.TempDir(), testOutboxFile))
+	if err != nil {
+		t.Fatalf("Unexpected error opening outbox: %s", err)
+	}
+
+	err = outbox.Run(context.Background(), -testOutboxInterval)
+	if !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Unexpected error from Run with negative interval: %v", err)
+	}
+}
+
+func ExampleOpenOutbox() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	outboxDir, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	defer func() {
+		_ = os.RemoveAll(outboxDir)
+	}()
+
+	outbox, err := OpenOutbox(client, filepath.Join(outboxDir, "outbox.json"))
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	err = outbox.Enqueue("botID", &api.StatsUpdate{Stats: &api.Stats{GuildCount: 100}})
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	err = outbox.Flush(context.Background())
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	fmt.Printf("Pending: %d\n", outbox.Pending())
+	// Output: Pending: 0
+}
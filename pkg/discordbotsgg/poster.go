@@ -0,0 +1,205 @@
+package discordbotsgg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// ErrPosterStarted is returned when starting a *Poster which is already
+// running.
+const ErrPosterStarted = constError("poster already started")
+
+// StatsProvider returns the current stats of a bot to be posted.
+type StatsProvider func(ctx context.Context) (*api.StatsUpdate, error)
+
+// PostResult is the result of a *Poster posting stats.
+type PostResult struct {
+	Time          time.Time          // The time of the post.
+	StatsUpdate   *api.StatsUpdate   // The stats returned by the StatsProvider, if any.
+	StatsResponse *api.StatsResponse // The API response, if the stats were posted.
+	Skipped       bool               // Whether posting was skipped because the stats were unchanged.
+	Err           error              // The error returned by the StatsProvider or the API, if any.
+}
+
+// Poster periodically posts the stats of a bot returned by a StatsProvider,
+// skipping posts when the stats are unchanged since the last successful post.
+type Poster struct {
+	// ErrorHandler, if set, is called with every error returned by the
+	// StatsProvider or the API. It should be set before calling Start.
+	ErrorHandler func(err error)
+
+	client     *Client
+	botID      string
+	interval   time.Duration
+	provider   StatsProvider
+	mutex      sync.Mutex
+	lastResult *PostResult
+	lastPosted *api.StatsUpdate
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewPoster returns a new *Poster posting the stats of the given botID,
+// returned by the provided StatsProvider, every interval.
+func NewPoster(client *Client, botID string, interval time.Duration, provider StatsProvider) *Poster {
+	return &Poster{
+		client:   client,
+		botID:    botID,
+		interval: interval,
+		provider: provider,
+	}
+}
+
+// Start starts posting stats in the background, immediately and then every
+// interval, until Stop is called or the provided context is done. It returns
+// ErrInvalidInterval if the interval of the *Poster is not positive.
+func (poster *Poster) Start(ctx context.Context) error {
+	if poster.interval <= 0 {
+		return ErrInvalidInterval
+	}
+
+	poster.mutex.Lock()
+	defer poster.mutex.Unlock()
+
+	if poster.cancel != nil {
+		return ErrPosterStarted
+	}
+
+	ctx, poster.cancel = context.WithCancel(ctx)
+	poster.done = make(chan struct{})
+
+	go poster.run(ctx, poster.done)
+
+	return nil
+}
+
+// Stop stops posting stats and waits for any post in progress to finish.
+func (poster *Poster) Stop() {
+	poster.mutex.Lock()
+	cancel, done := poster.cancel, poster.done
+	poster.cancel, poster.done = nil, nil
+	poster.mutex.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+}
+
+// LastResult returns the result of the last post, or nil if stats have not
+// been posted yet.
+func (poster *Poster) LastResult() *PostResult {
+	poster.mutex.Lock()
+	defer poster.mutex.Unlock()
+
+	if poster.lastResult == nil {
+		return nil
+	}
+
+	lastResult := *poster.lastResult
+
+	return &lastResult
+}
+
+// Post posts the current stats immediately, unless they are unchanged since
+// the last successful post.
+func (poster *Poster) Post(ctx context.Context) *PostResult {
+	result := &PostResult{Time: time.Now()}
+
+	result.StatsUpdate, result.Err = poster.provider(ctx)
+
+	if result.Err == nil {
+		poster.mutex.Lock()
+		result.Skipped = poster.lastPosted != nil && statsUpdatesEqual(result.StatsUpdate, poster.lastPosted)
+		poster.mutex.Unlock()
+	}
+
+	if result.Err == nil && !result.Skipped {
+		result.StatsResponse, result.Err = poster.client.UpdateWithContext(ctx, poster.botID, result.StatsUpdate)
+	}
+
+	poster.mutex.Lock()
+
+	poster.lastResult = result
+
+	if result.Err == nil && !result.Skipped {
+		poster.lastPosted = copyStatsUpdate(result.StatsUpdate)
+	}
+
+	poster.mutex.Unlock()
+
+	if result.Err != nil && poster.ErrorHandler != nil {
+		poster.ErrorHandler(result.Err)
+	}
+
+	return result
+}
+
+func (poster *Poster) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer poster.finish(done)
+
+	ticker := time.NewTicker(poster.interval)
+	defer ticker.Stop()
+
+	for {
+		poster.Post(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// finish clears the state of the run closing the given done channel, unless
+// Stop already has, so the *Poster can be started again once the context
+// provided to Start is done.
+func (poster *Poster) finish(done chan struct{}) {
+	poster.mutex.Lock()
+	defer poster.mutex.Unlock()
+
+	if poster.done != done {
+		return
+	}
+
+	poster.cancel()
+	poster.cancel, poster.done = nil, nil
+}
+
+func statsUpdatesEqual(statsUpdate, other *api.StatsUpdate) bool {
+	if statsUpdate == nil || other == nil {
+		return statsUpdate == other
+	}
+
+	if statsUpdate.ShardID != other.ShardID {
+		return false
+	}
+
+	if statsUpdate.Stats == nil || other.Stats == nil {
+		return statsUpdate.Stats == other.Stats
+	}
+
+	return *statsUpdate.Stats == *other.Stats
+}
+
+func copyStatsUpdate(statsUpdate *api.StatsUpdate) *api.StatsUpdate {
+	if statsUpdate == nil {
+		return nil
+	}
+
+	statsUpdateCopy := &api.StatsUpdate{ShardID: statsUpdate.ShardID}
+
+	if statsUpdate.Stats != nil {
+		stats := *statsUpdate.Stats
+		statsUpdateCopy.Stats = &stats
+	}
+
+	return statsUpdateCopy
+}
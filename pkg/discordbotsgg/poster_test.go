@@ -0,0 +1,212 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const testPostInterval = 5 * time.Millisecond
+
+type testStatsProvider struct {
+	mutex      sync.Mutex
+	guildCount int
+	err        error
+	calls      int
+}
+
+func (provider *testStatsProvider) Stats(context.Context) (*api.StatsUpdate, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	provider.calls++
+
+	if provider.err != nil {
+		return nil, provider.err
+	}
+
+	return &api.StatsUpdate{Stats: &api.Stats{GuildCount: provider.guildCount}}, nil
+}
+
+func TestPoster_Post(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	provider := &testStatsProvider{guildCount: testGuildCount}
+	poster := NewPoster(client, testBotID, time.Hour, provider.Stats)
+
+	var handledErr error
+
+	poster.ErrorHandler = func(err error) {
+		handledErr = err
+	}
+
+	if poster.LastResult() != nil {
+		t.Fatalf("Unexpected result before posting")
+	}
+
+	result := poster.Post(context.Background())
+	if result.Err != nil || result.Skipped || result.StatsResponse.GuildCount != testGuildCount {
+		t.Fatalf("Unexpected first post result: %+v", result)
+	}
+
+	result = poster.Post(context.Background())
+	if result.Err != nil || !result.Skipped || result.StatsResponse != nil {
+		t.Errorf("Unexpected unchanged post result: %+v", result)
+	}
+
+	provider.guildCount++
+
+	result = poster.Post(context.Background())
+	if result.Err != nil || result.Skipped || result.StatsResponse.GuildCount != testGuildCount+1 {
+		t.Errorf("Unexpected changed post result: %+v", result)
+	}
+
+	provider.err = errors.New("stats unavailable")
+
+	result = poster.Post(context.Background())
+	if result.Err == nil || handledErr != result.Err {
+		t.Errorf("Unexpected provider error result: %+v %v", result, handledErr)
+	}
+
+	if lastResult := poster.LastResult(); lastResult.Err != result.Err {
+		t.Errorf("Unexpected last result: %+v", lastResult)
+	}
+}
+
+func TestPoster_Start(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	provider := &testStatsProvider{guildCount: testGuildCount}
+	poster := NewPoster(client, testBotID, testPostInterval, provider.Stats)
+
+	err := poster.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error starting poster: %s", err)
+	}
+
+	err = poster.Start(context.Background())
+	if !errors.Is(err, ErrPosterStarted) {
+		t.Errorf("Unexpected error starting poster twice: %v", err)
+	}
+
+	time.Sleep(5 * testPostInterval)
+
+	poster.Stop()
+	poster.Stop()
+
+	provider.mutex.Lock()
+	calls := provider.calls
+	provider.mutex.Unlock()
+
+	if calls < 2 {
+		t.Errorf("Unexpected stats provider calls: %d", calls)
+	}
+
+	lastResult := poster.LastResult()
+	if lastResult == nil || lastResult.Err != nil || !lastResult.Skipped {
+		t.Errorf("Unexpected last result: %+v", lastResult)
+	}
+
+	time.Sleep(2 * testPostInterval)
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.calls != calls {
+		t.Errorf("Unexpected stats provider calls after stop: %d", provider.calls)
+	}
+}
+
+func TestPoster_Start_invalidInterval(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	provider := &testStatsProvider{guildCount: testGuildCount}
+
+	for _, interval := range []time.Duration{0, -testPostInterval} {
+		poster := NewPoster(client, testBotID, interval, provider.Stats)
+
+		err := poster.Start(context.Background())
+		if !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("Unexpected error starting poster with interval %s: %v", interval, err)
+		}
+	}
+}
+
+func TestPoster_Start_contextDone(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	provider := &testStatsProvider{guildCount: testGuildCount}
+	poster := NewPoster(client, testBotID, testPostInterval, provider.Stats)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := poster.Start(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error starting poster: %s", err)
+	}
+
+	poster.mutex.Lock()
+	done := poster.done
+	poster.mutex.Unlock()
+
+	cancel()
+	<-done
+
+	err = poster.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error restarting poster after context done: %s", err)
+	}
+
+	poster.Stop()
+}
+
+func TestStatsUpdatesEqual(t *testing.T) {
+	statsUpdate := &api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}, ShardID: 1}
+
+	if !statsUpdatesEqual(statsUpdate, copyStatsUpdate(statsUpdate)) {
+		t.Errorf("Expected copied stats update to be equal")
+	}
+
+	if statsUpdatesEqual(statsUpdate, &api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}}) {
+		t.Errorf("Expected stats updates for different shards not to be equal")
+	}
+
+	if statsUpdatesEqual(statsUpdate, &api.StatsUpdate{ShardID: 1}) || statsUpdatesEqual(statsUpdate, nil) {
+		t.Errorf("Expected stats updates with nil stats not to be equal")
+	}
+
+	if copyStatsUpdate(nil) != nil || copyStatsUpdate(&api.StatsUpdate{}).Stats != nil {
+		t.Errorf("Unexpected copy of nil stats")
+	}
+}
+
+func ExampleNewPoster() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	provider := func(ctx context.Context) (*api.StatsUpdate, error) {
+		return &api.StatsUpdate{Stats: &api.Stats{GuildCount: 100}}, nil
+	}
+
+	poster := NewPoster(client, "botID", 30*time.Minute, provider)
+	poster.ErrorHandler = func(err error) {
+		fmt.Printf("Error: %s\n", err)
+	}
+
+	result := poster.Post(context.Background())
+
+	fmt.Printf("%s", result.StatsResponse)
+	// Output: {"guildCount":100}
+}
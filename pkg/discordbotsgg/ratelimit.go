@@ -0,0 +1,108 @@
+package discordbotsgg
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitGlobal    = "X-RateLimit-Global"
+)
+
+// RateLimit is the rate limit state reported by the discord.bots.gg API in
+// response headers.
+type RateLimit struct {
+	Limit     int       // The number of requests permitted per timeframe, or 0 if not reported.
+	Remaining int       // The number of requests remaining in the current timeframe.
+	Reset     time.Time // The time at which the current timeframe resets.
+}
+
+// AdaptiveLimiter is a Limiter which can adjust its state to the rate limits
+// reported by the API. Limiters set on a *Client which implement this
+// interface are adjusted after every response carrying rate limit headers.
+type AdaptiveLimiter interface {
+	Limiter
+	Adjust(rateLimit *RateLimit)
+}
+
+// parseRateLimit returns the *RateLimit reported in the given headers, if
+// any.
+func parseRateLimit(header http.Header, now time.Time) (*RateLimit, bool) {
+	remaining, err := strconv.Atoi(header.Get(headerRateLimitRemaining))
+	if err != nil {
+		return nil, false
+	}
+
+	rateLimit := &RateLimit{
+		Remaining: remaining,
+		Reset:     now,
+	}
+
+	if limit, limitErr := strconv.Atoi(header.Get(headerRateLimitLimit)); limitErr == nil {
+		rateLimit.Limit = limit
+	}
+
+	if resetAfter, ok := retryAfterDelay(header, now); ok {
+		rateLimit.Reset = now.Add(resetAfter)
+	}
+
+	return rateLimit, true
+}
+
+func isGlobalRateLimit(header http.Header) bool {
+	return strings.EqualFold(header.Get(headerRateLimitGlobal), "true")
+}
+
+// globalPause blocks all requests from a *Client until a global rate limit
+// reported by the API has reset.
+type globalPause struct {
+	mutex sync.Mutex
+	until time.Time
+}
+
+func (pause *globalPause) wait(ctx context.Context) error {
+	pause.mutex.Lock()
+	wait := time.Until(pause.until)
+	pause.mutex.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	return sleepWithContext(ctx, wait)
+}
+
+func (pause *globalPause) extend(until time.Time) {
+	pause.mutex.Lock()
+	defer pause.mutex.Unlock()
+
+	if until.After(pause.until) {
+		pause.until = until
+	}
+}
+
+// observeRateLimit adjusts the given Limiter and the *Client global pause to
+// the rate limits reported in the *http.Response headers.
+func (client *Client) observeRateLimit(limiter Limiter, resp *http.Response) {
+	now := time.Now()
+
+	if rateLimit, ok := parseRateLimit(resp.Header, now); ok {
+		if adaptiveLimiter, isAdaptive := limiter.(AdaptiveLimiter); isAdaptive {
+			adaptiveLimiter.Adjust(rateLimit)
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests || !isGlobalRateLimit(resp.Header) {
+		return
+	}
+
+	if delay, ok := retryAfterDelay(resp.Header, now); ok {
+		client.globalPause.extend(now.Add(delay))
+	}
+}
@@ -0,0 +1,99 @@
+package discordbotsgg
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testGlobalRetryAfter        = 50 * time.Millisecond
+	testGlobalRetryAfterSeconds = "0.05"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Now()
+	header := make(http.Header)
+
+	if _, ok := parseRateLimit(header, now); ok {
+		t.Errorf("Unexpected rate limit without headers")
+	}
+
+	header.Set(headerRateLimitLimit, "10")
+	header.Set(headerRateLimitRemaining, "3")
+	header.Set(headerRateLimitResetAfter, "2")
+
+	rateLimit, ok := parseRateLimit(header, now)
+	if !ok {
+		t.Fatalf("Expected rate limit from headers")
+	}
+
+	if rateLimit.Limit != 10 || rateLimit.Remaining != 3 || !rateLimit.Reset.Equal(now.Add(2*time.Second)) {
+		t.Errorf("Unexpected rate limit: %+v", rateLimit)
+	}
+}
+
+func TestGlobalPause(t *testing.T) {
+	pause := &globalPause{}
+
+	err := pause.wait(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error waiting without pause: %s", err)
+	}
+
+	pause.extend(time.Now().Add(time.Hour))
+	pause.extend(time.Now())
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancelCtx()
+
+	err = pause.wait(ctx)
+	if err == nil {
+		t.Errorf("Expected error waiting on pause with expiring context")
+	}
+}
+
+func TestClient_observeRateLimit(t *testing.T) {
+	requests := 0
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requests++
+
+		header := make(http.Header)
+		header.Set(headerRateLimitRemaining, "0")
+		header.Set(headerRateLimitResetAfter, "0")
+
+		if requests == 1 {
+			header.Set(headerRateLimitGlobal, "true")
+			header.Set(headerRetryAfter, testGlobalRetryAfterSeconds)
+
+			return newTestResponse(req, http.StatusTooManyRequests, header, ""), nil
+		}
+
+		return newTestResponse(req, http.StatusOK, header, `{"guildCount":1}`), nil
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	client.RetryPolicy = newTestRetryPolicy()
+	client.RetryPolicy.MaxBackoff = time.Second
+
+	start := time.Now()
+
+	_, err := client.UpdateWithContext(context.Background(), testBotID, nil)
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	if elapsed := time.Since(start); elapsed < testGlobalRetryAfter {
+		t.Errorf("Global rate limit not honored, elapsed: %s", elapsed)
+	}
+
+	bucket := client.UpdateLimiter.(*TokenBucket)
+
+	if bucket.tokens > 0 {
+		t.Errorf("Unexpected tokens after exhausted rate limit: %f", bucket.tokens)
+	}
+}
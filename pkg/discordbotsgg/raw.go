@@ -0,0 +1,99 @@
+package discordbotsgg
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// WithRawJSON makes the *Client retain the raw JSON of every decoded
+// response in the Raw field of the returned *api.Bot, *api.Page and
+// *api.StatsResponse, including each bot of a page, and the fields which are
+// not modelled in their Extra field, so they can be re-serialized losslessly.
+func WithRawJSON() Option {
+	return func(client *Client) {
+		client.retainRawJSON = true
+	}
+}
+
+// retainRawJSON sets the Raw and Extra fields of the given decoded
+// responseObject from the given response body.
+func retainRawJSON(respBody []byte, responseObject interface{}) error {
+	raw := append(json.RawMessage(nil), respBody...)
+
+	switch object := responseObject.(type) {
+	case *api.Bot:
+		object.Raw = raw
+
+		return retainExtra(respBody, object, &object.Extra)
+	case *api.StatsResponse:
+		object.Raw = raw
+
+		return retainExtra(respBody, object, &object.Extra)
+	case *api.Page:
+		object.Raw = raw
+
+		err := retainExtra(respBody, object, &object.Extra)
+		if err != nil {
+			return err
+		}
+
+		return retainPageBots(respBody, object)
+	}
+
+	return nil
+}
+
+// retainPageBots sets the Raw and Extra fields of each bot of the given
+// decoded *api.Page from the given response body.
+func retainPageBots(respBody []byte, page *api.Page) error {
+	rawPage := &struct {
+		Bots []json.RawMessage `json:"bots"`
+	}{}
+
+	err := json.Unmarshal(respBody, rawPage)
+	if err != nil {
+		return err
+	}
+
+	for i, rawBot := range rawPage.Bots {
+		if i >= len(page.Bots) || page.Bots[i] == nil {
+			continue
+		}
+
+		page.Bots[i].Raw = rawBot
+
+		err = retainExtra(rawBot, page.Bots[i], &page.Bots[i].Extra)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retainExtra sets extra to the fields of the given JSON object which the
+// type of the given decoded object does not model, if any.
+func retainExtra(data []byte, object interface{}, extra *map[string]json.RawMessage) error {
+	var fields map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	knownFields := api.NewJSONFields(reflect.TypeOf(object).Elem())
+
+	for key := range fields {
+		if _, ok := knownFields.Lookup(key); ok {
+			delete(fields, key)
+		}
+	}
+
+	if len(fields) > 0 {
+		*extra = fields
+	}
+
+	return nil
+}
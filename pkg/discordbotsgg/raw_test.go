@@ -0,0 +1,69 @@
+package discordbotsgg
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+func TestClient_WithRawJSON(t *testing.T) {
+	client := NewClientWithOptions(WithHTTPClient(mock.NewHTTPClient()), WithRawJSON())
+	defer client.Close()
+
+	bot, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if !json.Valid(bot.Raw) || string(bot.Extra["deleted"]) != "false" {
+		t.Errorf("Unexpected raw bot: %s %v", bot.Raw, bot.Extra)
+	}
+
+	page, err := client.QueryBotsWithContext(context.Background(), &api.QueryParameters{})
+	if err != nil {
+		t.Fatalf(queryBotsErrorMessage, err)
+	}
+
+	if !json.Valid(page.Raw) || len(page.Bots) == 0 {
+		t.Fatalf("Unexpected raw page: %s", page.Raw)
+	}
+
+	for _, pageBot := range page.Bots {
+		rawBot := &api.Bot{}
+
+		err = json.Unmarshal(pageBot.Raw, rawBot)
+		if err != nil || rawBot.UserID != pageBot.UserID {
+			t.Errorf("Unexpected raw page bot: %s", pageBot.Raw)
+		}
+
+		if string(pageBot.Extra["deleted"]) != "false" {
+			t.Errorf("Unexpected page bot extra fields: %v", pageBot.Extra)
+		}
+	}
+
+	statsResponse, err := client.UpdateWithContext(context.Background(), testBotID, &api.StatsUpdate{Stats: &api.Stats{}})
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	if !json.Valid(statsResponse.Raw) {
+		t.Errorf("Unexpected raw stats response: %s", statsResponse.Raw)
+	}
+}
+
+func TestClient_QueryBotWithContext_noRawJSON(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	bot, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if bot.Raw != nil || bot.Extra != nil {
+		t.Errorf("Unexpected raw bot retained by default: %s %v", bot.Raw, bot.Extra)
+	}
+}
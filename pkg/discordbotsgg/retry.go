@@ -0,0 +1,161 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultMinBackoff  = 500 * time.Millisecond
+	defaultMaxBackoff  = 30 * time.Second
+	defaultJitter      = 0.2
+
+	headerRetryAfter          = "Retry-After"
+	headerRateLimitReset      = "X-RateLimit-Reset"
+	headerRateLimitResetAfter = "X-RateLimit-Reset-After"
+)
+
+// RetryPolicy configures automatic retries of failed requests. Requests are
+// retried when the API responds with one of the RetryableStatusCodes or when
+// the request fails before a response is received, unless the request context
+// is done.
+type RetryPolicy struct {
+	MaxAttempts          int           // The total number of attempts, including the first. Values below 2 disable retries.
+	MinBackoff           time.Duration // The backoff before the first retry. Doubled for each later retry.
+	MaxBackoff           time.Duration // The maximum backoff. Retry-After waits longer than this are not retried.
+	Jitter               float64       // The fraction, between 0 and 1, of each backoff to randomize.
+	RetryableStatusCodes []int         // The response status codes to retry.
+}
+
+// DefaultRetryPolicy returns a new *RetryPolicy retrying rate limited and
+// server error responses up to three attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultMaxAttempts,
+		MinBackoff:  defaultMinBackoff,
+		MaxBackoff:  defaultMaxBackoff,
+		Jitter:      defaultJitter,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// retryDelay returns how long to wait before retrying a failed attempt and
+// whether the attempt should be retried at all.
+func (policy *RetryPolicy) retryDelay(ctx context.Context, attempt int, err error) (time.Duration, bool) {
+	if policy == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	delay := policy.backoff(attempt)
+
+	var apiErr *APIError
+
+	if !errors.As(err, &apiErr) {
+		return delay, true
+	}
+
+	if !policy.retryable(apiErr.StatusCode) {
+		return 0, false
+	}
+
+	if retryAfter, ok := retryAfterDelay(apiErr.Header, time.Now()); ok {
+		if policy.MaxBackoff > 0 && retryAfter > policy.MaxBackoff {
+			return 0, false
+		}
+
+		delay = retryAfter
+	}
+
+	return delay, true
+}
+
+func (policy *RetryPolicy) retryable(statusCode int) bool {
+	for _, retryableStatusCode := range policy.RetryableStatusCodes {
+		if statusCode == retryableStatusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := policy.MinBackoff
+
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+
+		if policy.MaxBackoff > 0 && backoff >= policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+			break
+		}
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	if policy.Jitter > 0 {
+		// #nosec G404 -- Backoff jitter does not require a secure source.
+		backoff -= time.Duration(policy.Jitter * rand.Float64() * float64(backoff))
+	}
+
+	return backoff
+}
+
+// retryAfterDelay returns how long the API asked to wait before retrying,
+// using the Retry-After header or the rate limit reset headers.
+func retryAfterDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+
+	if retryAfter := header.Get(headerRetryAfter); retryAfter != "" {
+		if seconds, err := strconv.ParseFloat(retryAfter, 64); err == nil {
+			return secondsToDuration(seconds), true
+		}
+
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(date.Sub(now)), true
+		}
+	}
+
+	if resetAfter := header.Get(headerRateLimitResetAfter); resetAfter != "" {
+		if seconds, err := strconv.ParseFloat(resetAfter, 64); err == nil {
+			return secondsToDuration(seconds), true
+		}
+	}
+
+	if reset := header.Get(headerRateLimitReset); reset != "" {
+		if seconds, err := strconv.ParseFloat(reset, 64); err == nil {
+			resetTime := time.Unix(0, 0).Add(secondsToDuration(seconds))
+
+			return nonNegative(resetTime.Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return nonNegative(time.Duration(seconds * float64(time.Second)))
+}
+
+func nonNegative(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+
+	return duration
+}
@@ -0,0 +1,201 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+const (
+	testMaxAttempts = 3
+	testMinBackoff  = time.Millisecond
+	testMaxBackoff  = 4 * time.Millisecond
+)
+
+func newTestRetryPolicy() *RetryPolicy {
+	retryPolicy := DefaultRetryPolicy()
+	retryPolicy.MaxAttempts = testMaxAttempts
+	retryPolicy.MinBackoff = testMinBackoff
+	retryPolicy.MaxBackoff = testMaxBackoff
+	retryPolicy.Jitter = 0
+
+	return retryPolicy
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	retryPolicy := DefaultRetryPolicy()
+
+	if !retryPolicy.retryable(http.StatusTooManyRequests) {
+		t.Errorf("Expected %d to be retryable", http.StatusTooManyRequests)
+	}
+
+	if retryPolicy.retryable(http.StatusNotFound) {
+		t.Errorf("Expected %d not to be retryable", http.StatusNotFound)
+	}
+}
+
+func TestRetryPolicy_retryDelay(t *testing.T) {
+	var retryPolicy *RetryPolicy
+
+	ctx := context.Background()
+	rateLimited := &APIError{StatusCode: http.StatusTooManyRequests}
+
+	if _, retry := retryPolicy.retryDelay(ctx, 1, rateLimited); retry {
+		t.Errorf("Unexpected retry with nil policy")
+	}
+
+	retryPolicy = newTestRetryPolicy()
+
+	delay, retry := retryPolicy.retryDelay(ctx, 1, rateLimited)
+	if !retry || delay != testMinBackoff {
+		t.Errorf("Unexpected first retry delay: %s %t", delay, retry)
+	}
+
+	delay, retry = retryPolicy.retryDelay(ctx, 2, errors.New("connection reset"))
+	if !retry || delay != 2*testMinBackoff {
+		t.Errorf("Unexpected second retry delay: %s %t", delay, retry)
+	}
+
+	if _, retry = retryPolicy.retryDelay(ctx, testMaxAttempts, rateLimited); retry {
+		t.Errorf("Unexpected retry after max attempts")
+	}
+
+	if _, retry = retryPolicy.retryDelay(ctx, 1, &APIError{StatusCode: http.StatusNotFound}); retry {
+		t.Errorf("Unexpected retry of non-retryable status code")
+	}
+
+	canceledCtx, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+
+	if _, retry = retryPolicy.retryDelay(canceledCtx, 1, context.Canceled); retry {
+		t.Errorf("Unexpected retry with canceled context")
+	}
+
+	rateLimited.Header = make(http.Header)
+	rateLimited.Header.Set(headerRetryAfter, "0.003")
+
+	delay, retry = retryPolicy.retryDelay(ctx, 1, rateLimited)
+	if !retry || delay != 3*time.Millisecond {
+		t.Errorf("Unexpected Retry-After delay: %s %t", delay, retry)
+	}
+
+	rateLimited.Header.Set(headerRetryAfter, "60")
+
+	if _, retry = retryPolicy.retryDelay(ctx, 1, rateLimited); retry {
+		t.Errorf("Unexpected retry with Retry-After longer than max backoff")
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	retryPolicy := newTestRetryPolicy()
+
+	expected := []time.Duration{testMinBackoff, 2 * testMinBackoff, testMaxBackoff, testMaxBackoff}
+
+	for i, backoff := range expected {
+		got := retryPolicy.backoff(i + 1)
+		if got != backoff {
+			t.Errorf("Unexpected backoff for attempt %d. Got: %s. Expected: %s.", i+1, got, backoff)
+		}
+	}
+
+	retryPolicy.Jitter = 1
+
+	got := retryPolicy.backoff(1)
+	if got < 0 || got > testMinBackoff {
+		t.Errorf("Unexpected backoff with jitter: %s", got)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	now := time.Now()
+
+	headers := map[string][2]string{
+		"Retry-After seconds": {headerRetryAfter, "2"},
+		"Retry-After date":    {headerRetryAfter, now.Add(2 * time.Second).UTC().Format(http.TimeFormat)},
+		"Reset-After":         {headerRateLimitResetAfter, "2"},
+		"Reset":               {headerRateLimitReset, strconv.FormatInt(now.Add(2*time.Second).Unix(), 10)},
+	}
+
+	for name, keyValue := range headers {
+		header := make(http.Header)
+		header.Set(keyValue[0], keyValue[1])
+
+		delay, ok := retryAfterDelay(header, now)
+		if !ok || delay <= time.Second || delay > 2*time.Second {
+			t.Errorf("Unexpected %s delay: %s %t", name, delay, ok)
+		}
+	}
+
+	if _, ok := retryAfterDelay(http.Header{}, now); ok {
+		t.Errorf("Unexpected delay without headers")
+	}
+
+	if _, ok := retryAfterDelay(nil, now); ok {
+		t.Errorf("Unexpected delay with nil headers")
+	}
+}
+
+func TestClient_doRequest_retry(t *testing.T) {
+	attempts := 0
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+
+		if req.Method == http.MethodGet {
+			return newTestResponse(req, http.StatusServiceUnavailable, nil, ""), nil
+		}
+
+		reqBody, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempts < testMaxAttempts {
+			return newTestResponse(req, http.StatusServiceUnavailable, nil, ""), nil
+		}
+
+		return newTestResponse(req, http.StatusOK, nil, string(reqBody)), nil
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	client.RetryPolicy = newTestRetryPolicy()
+
+	statsUpdate := &api.StatsUpdate{
+		Stats: &api.Stats{
+			GuildCount: testGuildCount,
+			ShardCount: testShardCount,
+		},
+	}
+
+	statsResponse, err := client.UpdateWithContext(context.Background(), testBotID, statsUpdate)
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	if attempts != testMaxAttempts {
+		t.Errorf("Unexpected attempts. Got: %d. Expected: %d.", attempts, testMaxAttempts)
+	}
+
+	if statsResponse.GuildCount != testGuildCount {
+		t.Errorf("Unexpected guild count stat from rewound body: %d", statsResponse.GuildCount)
+	}
+
+	attempts = 0
+
+	_, err = client.QueryBotWithContext(context.Background(), testBotID, false)
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("Unexpected error after exhausting retries: %v", err)
+	}
+
+	if attempts != testMaxAttempts {
+		t.Errorf("Unexpected attempts. Got: %d. Expected: %d.", attempts, testMaxAttempts)
+	}
+}
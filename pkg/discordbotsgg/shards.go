@@ -0,0 +1,221 @@
+package discordbotsgg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// ErrNoShardStats is returned when a *ShardStatsAggregator has no live shard
+// stats to post.
+const ErrNoShardStats = constError("no shard stats")
+
+// AggregateMode selects how a *ShardStatsAggregator posts shard stats.
+type AggregateMode int
+
+const (
+	// AggregateTotal posts a single update with the total guild count of all
+	// live shards.
+	AggregateTotal AggregateMode = iota
+
+	// AggregatePerShard posts an update for each live shard with its own
+	// guild count and shard ID.
+	AggregatePerShard
+)
+
+// shardStatsUpdate is the request body of a per-shard update. Unlike
+// api.StatsUpdate, it always includes the shard ID, as the API takes an update
+// without one for the stats of the whole bot.
+type shardStatsUpdate struct {
+	*api.Stats
+	ShardID int `json:"shardID"`
+}
+
+type shardStats struct {
+	guildCount int
+	updated    time.Time
+}
+
+// ShardStatsAggregator collects the guild counts of the shards of a bot,
+// which may be reported concurrently, and posts them to the API. Shards not
+// reported within the stale timeout are dropped. A *ShardStatsAggregator is
+// safe for concurrent use.
+type ShardStatsAggregator struct {
+	client     *Client
+	botID      string
+	mode       AggregateMode
+	staleAfter time.Duration
+	mutex      sync.Mutex
+	shardCount int
+	shards     map[int]*shardStats
+	now        func() time.Time
+}
+
+// NewShardStatsAggregator returns a new *ShardStatsAggregator posting the
+// shard stats of the given botID using the given AggregateMode. Shards not
+// reported for longer than staleAfter are dropped. A staleAfter of zero never
+// drops shards.
+func NewShardStatsAggregator(client *Client, botID string, mode AggregateMode, staleAfter time.Duration) *ShardStatsAggregator {
+	return &ShardStatsAggregator{
+		client:     client,
+		botID:      botID,
+		mode:       mode,
+		staleAfter: staleAfter,
+		shards:     make(map[int]*shardStats),
+		now:        time.Now,
+	}
+}
+
+// SetShardCount sets the total number of shards of the bot. By default, the
+// shard count posted is the number of live shards.
+func (aggregator *ShardStatsAggregator) SetShardCount(shardCount int) {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	aggregator.shardCount = shardCount
+}
+
+// Set records the guild count of the given shardID.
+func (aggregator *ShardStatsAggregator) Set(shardID, guildCount int) {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	aggregator.shards[shardID] = &shardStats{
+		guildCount: guildCount,
+		updated:    aggregator.now(),
+	}
+}
+
+// Remove drops the given shardID, such as when a shard shuts down.
+func (aggregator *ShardStatsAggregator) Remove(shardID int) {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	delete(aggregator.shards, shardID)
+}
+
+// Stats returns a single update with the total guild count of all live
+// shards. It satisfies the StatsProvider type so it can be used with a
+// *Poster.
+func (aggregator *ShardStatsAggregator) Stats(context.Context) (*api.StatsUpdate, error) {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	aggregator.pruneStale()
+
+	if len(aggregator.shards) == 0 {
+		return nil, ErrNoShardStats
+	}
+
+	stats := &api.Stats{ShardCount: aggregator.currentShardCount()}
+
+	for _, shard := range aggregator.shards {
+		stats.GuildCount += shard.guildCount
+	}
+
+	return &api.StatsUpdate{Stats: stats}, nil
+}
+
+// ShardStatsUpdates returns an update for each live shard, ordered by shard
+// ID. The api.StatsUpdate of shard 0 is encoded without its shard ID, so the
+// updates should be posted with Post, which always includes it.
+func (aggregator *ShardStatsAggregator) ShardStatsUpdates() []*api.StatsUpdate {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	aggregator.pruneStale()
+
+	shardCount := aggregator.currentShardCount()
+	statsUpdates := make([]*api.StatsUpdate, 0, len(aggregator.shards))
+
+	for shardID, shard := range aggregator.shards {
+		statsUpdates = append(statsUpdates, &api.StatsUpdate{
+			Stats: &api.Stats{
+				GuildCount: shard.guildCount,
+				ShardCount: shardCount,
+			},
+			ShardID: shardID,
+		})
+	}
+
+	sort.Slice(statsUpdates, func(i, j int) bool {
+		return statsUpdates[i].ShardID < statsUpdates[j].ShardID
+	})
+
+	return statsUpdates
+}
+
+// Post posts the live shard stats according to the AggregateMode of the
+// *ShardStatsAggregator, returning the API responses. In AggregatePerShard
+// mode, every shard is posted even if posting another fails, and the first
+// error is returned.
+func (aggregator *ShardStatsAggregator) Post(ctx context.Context) ([]*api.StatsResponse, error) {
+	if aggregator.mode != AggregatePerShard {
+		statsUpdate, err := aggregator.Stats(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		statsResponse, err := aggregator.client.UpdateWithContext(ctx, aggregator.botID, statsUpdate)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*api.StatsResponse{statsResponse}, nil
+	}
+
+	statsUpdates := aggregator.ShardStatsUpdates()
+	if len(statsUpdates) == 0 {
+		return nil, ErrNoShardStats
+	}
+
+	var firstErr error
+
+	statsResponses := make([]*api.StatsResponse, 0, len(statsUpdates))
+
+	for _, statsUpdate := range statsUpdates {
+		statsResponse, err := aggregator.client.update(ctx, aggregator.botID, &shardStatsUpdate{
+			Stats:   statsUpdate.Stats,
+			ShardID: statsUpdate.ShardID,
+		})
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("shard %d: %w", statsUpdate.ShardID, err)
+			}
+
+			continue
+		}
+
+		statsResponses = append(statsResponses, statsResponse)
+	}
+
+	return statsResponses, firstErr
+}
+
+// pruneStale must be called with the mutex held.
+func (aggregator *ShardStatsAggregator) pruneStale() {
+	if aggregator.staleAfter <= 0 {
+		return
+	}
+
+	now := aggregator.now()
+
+	for shardID, shard := range aggregator.shards {
+		if now.Sub(shard.updated) > aggregator.staleAfter {
+			delete(aggregator.shards, shardID)
+		}
+	}
+}
+
+// currentShardCount must be called with the mutex held.
+func (aggregator *ShardStatsAggregator) currentShardCount() int {
+	if aggregator.shardCount > 0 {
+		return aggregator.shardCount
+	}
+
+	return len(aggregator.shards)
+}
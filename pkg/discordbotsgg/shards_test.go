@@ -0,0 +1,238 @@
+package discordbotsgg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+const testStaleAfter = time.Minute
+
+func TestShardStatsAggregator_Stats(t *testing.T) {
+	aggregator := NewShardStatsAggregator(nil, testBotID, AggregateTotal, testStaleAfter)
+
+	now := time.Now()
+	aggregator.now = func() time.Time { return now }
+
+	_, err := aggregator.Stats(context.Background())
+	if !errors.Is(err, ErrNoShardStats) {
+		t.Errorf("Unexpected error without shard stats: %v", err)
+	}
+
+	var waitGroup sync.WaitGroup
+
+	for shardID := 0; shardID < testShardCount; shardID++ {
+		waitGroup.Add(1)
+
+		go func(shardID int) {
+			defer waitGroup.Done()
+
+			aggregator.Set(shardID, testGuildCount)
+		}(shardID)
+	}
+
+	waitGroup.Wait()
+
+	statsUpdate, err := aggregator.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error aggregating shard stats: %s", err)
+	}
+
+	if statsUpdate.GuildCount != testShardCount*testGuildCount || statsUpdate.ShardCount != testShardCount {
+		t.Errorf("Unexpected aggregate stats: %+v", statsUpdate.Stats)
+	}
+
+	aggregator.Remove(0)
+	aggregator.SetShardCount(testShardCount + 1)
+
+	statsUpdate, err = aggregator.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error aggregating shard stats: %s", err)
+	}
+
+	if statsUpdate.GuildCount != (testShardCount-1)*testGuildCount || statsUpdate.ShardCount != testShardCount+1 {
+		t.Errorf("Unexpected aggregate stats after removing shard: %+v", statsUpdate.Stats)
+	}
+
+	now = now.Add(testStaleAfter / 2)
+	aggregator.Set(1, testGuildCount)
+
+	now = now.Add(testStaleAfter)
+
+	statsUpdate, err = aggregator.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error aggregating shard stats: %s", err)
+	}
+
+	if statsUpdate.GuildCount != testGuildCount {
+		t.Errorf("Unexpected aggregate stats after shards went stale: %+v", statsUpdate.Stats)
+	}
+}
+
+func TestShardStatsAggregator_ShardStatsUpdates(t *testing.T) {
+	aggregator := NewShardStatsAggregator(nil, testBotID, AggregatePerShard, 0)
+
+	aggregator.Set(2, 2)
+	aggregator.Set(0, 0)
+	aggregator.Set(1, 1)
+
+	statsUpdates := aggregator.ShardStatsUpdates()
+
+	if len(statsUpdates) != 3 {
+		t.Fatalf("Unexpected shard stats updates: %d", len(statsUpdates))
+	}
+
+	for i, statsUpdate := range statsUpdates {
+		if statsUpdate.ShardID != i || statsUpdate.GuildCount != i || statsUpdate.ShardCount != 3 {
+			t.Errorf("Unexpected shard stats update: %+v %+v", statsUpdate, statsUpdate.Stats)
+		}
+	}
+}
+
+func TestShardStatsAggregator_Post(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	for _, mode := range []AggregateMode{AggregateTotal, AggregatePerShard} {
+		aggregator := NewShardStatsAggregator(client, testBotID, mode, testStaleAfter)
+
+		_, err := aggregator.Post(context.Background())
+		if !errors.Is(err, ErrNoShardStats) {
+			t.Errorf("Unexpected error posting without shard stats: %v", err)
+		}
+
+		aggregator.Set(0, testGuildCount)
+		aggregator.Set(1, testGuildCount)
+
+		statsResponses, err := aggregator.Post(context.Background())
+		if err != nil {
+			t.Fatalf(updateBotStatsErrorMessage, err)
+		}
+
+		expected := 1
+
+		if mode == AggregatePerShard {
+			expected = 2
+		}
+
+		if len(statsResponses) != expected {
+			t.Errorf("Unexpected responses for mode %d: %d", mode, len(statsResponses))
+		}
+	}
+}
+
+func TestShardStatsAggregator_Post_shardID(t *testing.T) {
+	var (
+		mutex  sync.Mutex
+		bodies []map[string]json.RawMessage
+	)
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := make(map[string]json.RawMessage)
+
+		err = json.Unmarshal(body, &fields)
+		if err != nil {
+			return nil, err
+		}
+
+		mutex.Lock()
+		bodies = append(bodies, fields)
+		mutex.Unlock()
+
+		return newTestResponse(req, http.StatusOK, nil, string(body)), nil
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	for _, mode := range []AggregateMode{AggregateTotal, AggregatePerShard} {
+		aggregator := NewShardStatsAggregator(client, testBotID, mode, testStaleAfter)
+		aggregator.Set(0, testGuildCount)
+		aggregator.Set(1, testGuildCount)
+
+		_, err := aggregator.Post(context.Background())
+		if err != nil {
+			t.Fatalf(updateBotStatsErrorMessage, err)
+		}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(bodies) != 3 {
+		t.Fatalf("Unexpected posted bodies: %v", bodies)
+	}
+
+	// The aggregate update has no shard ID, while every per-shard update
+	// has one, including shard 0.
+	if _, ok := bodies[0]["shardID"]; ok {
+		t.Errorf("Unexpected shard ID in aggregate update: %s", bodies[0]["shardID"])
+	}
+
+	for shardID, body := range bodies[1:] {
+		if string(body["shardID"]) != fmt.Sprint(shardID) {
+			t.Errorf("Unexpected shard ID in update of shard %d: %s", shardID, body["shardID"])
+		}
+	}
+}
+
+func TestShardStatsAggregator_Post_error(t *testing.T) {
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return newTestResponse(req, http.StatusUnauthorized, nil, ""), nil
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	aggregator := NewShardStatsAggregator(client, testBotID, AggregatePerShard, testStaleAfter)
+	aggregator.Set(1, testGuildCount)
+
+	_, err := aggregator.Post(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Unexpected error posting shard stats: %v", err)
+	}
+
+	aggregator = NewShardStatsAggregator(client, testBotID, AggregateTotal, testStaleAfter)
+	aggregator.Set(1, testGuildCount)
+
+	_, err = aggregator.Post(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Unexpected error posting aggregate stats: %v", err)
+	}
+}
+
+func ExampleNewShardStatsAggregator() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	aggregator := NewShardStatsAggregator(client, "botID", AggregateTotal, 10*time.Minute)
+	aggregator.SetShardCount(2)
+
+	// Each shard reports its own guild count, e.g. from its event handlers.
+	aggregator.Set(0, 60)
+	aggregator.Set(1, 40)
+
+	statsResponses, err := aggregator.Post(context.Background())
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	fmt.Printf("%s", statsResponses[0])
+	// Output: {"guildCount":100,"shardCount":2}
+}
@@ -0,0 +1,109 @@
+package discordbotsgg
+
+import (
+	"context"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// StreamBots streams every bot matching the given queryParameters, starting at
+// queryParameters.Page, on the returned bot channel. The next page of results
+// is fetched under the *Client query rate limit while the bots of the current
+// page are consumed, and no further pages are fetched until they have been.
+//
+// Both channels are closed when streaming stops. At most one error is sent on
+// the error channel, including the context error if the provided context is
+// done before every bot has been streamed. Callers that stop consuming early
+// should cancel the provided context to release resources.
+func (client *Client) StreamBots(
+	ctx context.Context,
+	queryParameters *api.QueryParameters,
+	opts ...CallOption,
+) (botStream <-chan *api.Bot, errStream <-chan error) {
+	bots := make(chan *api.Bot)
+	errs := make(chan error, 1)
+	pages := make(chan *api.Page)
+
+	params := api.QueryParameters{}
+
+	if queryParameters != nil {
+		params = *queryParameters
+	}
+
+	ctx, cancelCtx := context.WithCancel(ctx)
+
+	var fetchErr error
+
+	go func() {
+		defer close(pages)
+
+		fetchErr = client.fetchPages(ctx, &params, opts, pages)
+	}()
+
+	go func() {
+		defer close(errs)
+		defer close(bots)
+
+		err := streamPages(ctx, pages, bots)
+
+		cancelCtx()
+
+		// Drain the pages channel so the fetching goroutine can return.
+		for range pages {
+		}
+
+		if err == nil {
+			err = fetchErr
+		}
+
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return bots, errs
+}
+
+func (client *Client) fetchPages(
+	ctx context.Context,
+	queryParameters *api.QueryParameters,
+	opts []CallOption,
+	pages chan<- *api.Page,
+) error {
+	for {
+		page, err := client.QueryBotsWithContext(ctx, queryParameters, opts...)
+		if err != nil {
+			return err
+		}
+
+		if len(page.Bots) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case pages <- page:
+		}
+
+		if lastPage(page) {
+			return nil
+		}
+
+		queryParameters.Page++
+	}
+}
+
+func streamPages(ctx context.Context, pages <-chan *api.Page, bots chan<- *api.Bot) error {
+	for page := range pages {
+		for _, bot := range page.Bots {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case bots <- bot:
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+func TestClient_StreamBots(t *testing.T) {
+	httpClient, requests := newPagedHTTPClient()
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	bots, errs := client.StreamBots(context.Background(), &api.QueryParameters{Limit: testPagedLimit})
+
+	count := 0
+
+	for bot := range bots {
+		count++
+
+		expected := fmt.Sprintf("Test Bot %d", count)
+
+		if bot.Username != expected {
+			t.Errorf("Unexpected bot. Got: %s. Expected: %s.", bot, expected)
+		}
+	}
+
+	for err := range errs {
+		t.Errorf("Unexpected stream error: %s", err)
+	}
+
+	if count != testPagedCount || *requests != 3 {
+		t.Errorf("Unexpected bots and requests: %d %d", count, *requests)
+	}
+}
+
+func TestClient_StreamBots_prefetch(t *testing.T) {
+	const quietPeriod = 50 * time.Millisecond
+
+	httpClient, _ := newPagedHTTPClient()
+	pagedTransport := httpClient.Transport
+	started := make(chan int, testPagedCount)
+
+	httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		pageNumber, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		started <- pageNumber
+
+		return pagedTransport.RoundTrip(req)
+	})
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	bots, errs := client.StreamBots(ctx, &api.QueryParameters{Limit: testPagedLimit})
+
+	if pageNumber := <-started; pageNumber != 0 {
+		t.Fatalf("Unexpected first page requested: %d", pageNumber)
+	}
+
+	<-bots
+
+	// The next page is fetched while the first is still being consumed.
+	if pageNumber := <-started; pageNumber != 1 {
+		t.Fatalf("Unexpected page requested while consuming the first: %d", pageNumber)
+	}
+
+	// No further page is fetched until the first has been consumed.
+	select {
+	case pageNumber := <-started:
+		t.Fatalf("Unexpected page %d requested before the first was consumed", pageNumber)
+	case <-time.After(quietPeriod):
+	}
+
+	count := 1
+
+	for range bots {
+		count++
+	}
+
+	for err := range errs {
+		t.Errorf("Unexpected stream error: %s", err)
+	}
+
+	if pageNumber := <-started; count != testPagedCount || pageNumber != 2 {
+		t.Errorf("Unexpected bots and last page requested: %d %d", count, pageNumber)
+	}
+}
+
+func TestClient_StreamBots_canceled(t *testing.T) {
+	httpClient, _ := newPagedHTTPClient()
+
+	client := NewClient(httpClient, "")
+	defer client.Close()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	bots, errs := client.StreamBots(ctx, &api.QueryParameters{Limit: testPagedLimit})
+
+	<-bots
+	cancelCtx()
+
+	for range bots {
+	}
+
+	err := <-errs
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error from canceled stream: %v", err)
+	}
+}
+
+func TestClient_StreamBots_error(t *testing.T) {
+	client := NewClient(mock.NewHTTPClient(), "")
+	defer client.Close()
+
+	bots, errs := client.StreamBots(context.Background(), &api.QueryParameters{Unverified: true})
+
+	for bot := range bots {
+		t.Errorf("Unexpected bot from failed query: %s", bot)
+	}
+
+	err := <-errs
+	if !errors.Is(err, ErrTokenRequired) {
+		t.Errorf("Unexpected stream error: %v", err)
+	}
+}
+
+func ExampleClient_StreamBots() {
+	httpClient := mock.NewHTTPClient() // Substitute a real *http.Client here.
+
+	client := NewClient(httpClient, "apiToken")
+	defer client.Close()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	bots, errs := client.StreamBots(ctx, &api.QueryParameters{Lib: "discordgo"})
+
+	for bot := range bots {
+		fmt.Printf("Bot: %s\n", bot)
+	}
+
+	for err := range errs {
+		fmt.Printf("Error: %s\n", err)
+	}
+	// Output:
+	// Bot: Test Bot 1
+	// Bot: Test Bot 2
+}
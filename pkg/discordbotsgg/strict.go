@@ -0,0 +1,159 @@
+package discordbotsgg
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+)
+
+// SchemaDrift describes the fields of an API response which are not modelled
+// by the api package, such as fields added to the API.
+type SchemaDrift struct {
+	// Endpoint is the API endpoint of the response: "bot", "bots" or
+	// "stats".
+	Endpoint string
+
+	// URL is the URL of the request.
+	URL string
+
+	// UnknownFields are the paths of the unknown fields, such as
+	// "owner.deleted" or "bots[].deleted".
+	UnknownFields []string
+}
+
+// WithStrictDecoding makes the *Client check every response received from the
+// API, but not those served from its Cache, for fields which are not modelled
+// by the api package, calling the given handler with the unknown fields, if
+// any, and counting them in the *Client Metrics, if set. Responses are still
+// decoded as usual, so schema drift is reported without breaking callers. A
+// nil handler only counts the unknown fields.
+func WithStrictDecoding(handler func(drift *SchemaDrift)) Option {
+	return func(client *Client) {
+		client.strictDecoding = true
+		client.driftHandler = handler
+	}
+}
+
+// reportDrift reports any fields of the given response to the given queryURL
+// which the api package does not model, when strict decoding is enabled. It
+// is called for responses received from the API only, so cached responses
+// and 304 Not Modified renewals of them are not reported again. Responses
+// which are not valid JSON are left to fail decoding.
+func (client *Client) reportDrift(queryURL string, resp *response) {
+	if !client.strictDecoding || resp.statusCode == http.StatusNotModified {
+		return
+	}
+
+	endpoint := parseEndpointName(queryURL)
+
+	typ := responseType(endpoint)
+	if typ == nil {
+		return
+	}
+
+	var value interface{}
+
+	err := json.Unmarshal(resp.body, &value)
+	if err != nil {
+		return
+	}
+
+	var fields []string
+
+	unknownFields(value, typ, "", &fields)
+
+	if len(fields) == 0 {
+		return
+	}
+
+	sort.Strings(fields)
+
+	drift := &SchemaDrift{
+		Endpoint:      endpoint,
+		URL:           queryURL,
+		UnknownFields: fields,
+	}
+
+	client.metrics.observeDrift(drift)
+
+	if client.driftHandler != nil {
+		client.driftHandler(drift)
+	}
+}
+
+// responseType returns the api type of the responses of the given endpoint,
+// or nil for an unknown endpoint.
+func responseType(endpoint string) reflect.Type {
+	switch endpoint {
+	case endpointBot:
+		return reflect.TypeOf(api.Bot{})
+	case endpointBots:
+		return reflect.TypeOf(api.Page{})
+	case endpointStats:
+		return reflect.TypeOf(api.StatsResponse{})
+	default:
+		return nil
+	}
+}
+
+// unknownFields appends the paths of the fields of the given decoded JSON
+// value which the given type does not model to fields, recursing into JSON
+// objects and arrays.
+func unknownFields(value interface{}, typ reflect.Type, path string, fields *[]string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch jsonValue := value.(type) {
+	case map[string]interface{}:
+		unknownObjectFields(jsonValue, typ, path, fields)
+	case []interface{}:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return
+		}
+
+		for _, element := range jsonValue {
+			unknownFields(element, typ.Elem(), path+"[]", fields)
+		}
+	}
+}
+
+func unknownObjectFields(object map[string]interface{}, typ reflect.Type, path string, fields *[]string) {
+	switch typ.Kind() {
+	case reflect.Map:
+		for _, element := range object {
+			unknownFields(element, typ.Elem(), path+"[]", fields)
+		}
+	case reflect.Struct:
+		knownFields := api.NewJSONFields(typ)
+
+		for key, element := range object {
+			fieldPath := key
+
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+
+			field, ok := knownFields.Lookup(key)
+			if !ok {
+				appendUnique(fields, fieldPath)
+				continue
+			}
+
+			unknownFields(element, field.Type, fieldPath, fields)
+		}
+	}
+}
+
+func appendUnique(fields *[]string, field string) {
+	for _, existing := range *fields {
+		if existing == field {
+			return
+		}
+	}
+
+	*fields = append(*fields, field)
+}
@@ -0,0 +1,134 @@
+package discordbotsgg
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+func TestClient_strictDecoding(t *testing.T) {
+	var drifts []*SchemaDrift
+
+	metrics := NewMetrics()
+
+	client := NewClientWithOptions(
+		WithHTTPClient(mock.NewHTTPClient()),
+		WithBotToken(testBotToken),
+		WithMetrics(metrics),
+		WithStrictDecoding(func(drift *SchemaDrift) {
+			drifts = append(drifts, drift)
+		}),
+	)
+	defer client.Close()
+
+	bot, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	if bot.Username == "" {
+		t.Errorf("Unexpected bot decoded in strict mode: %+v", bot)
+	}
+
+	_, err = client.QueryBotsWithContext(context.Background(), &api.QueryParameters{})
+	if err != nil {
+		t.Fatalf(queryBotsErrorMessage, err)
+	}
+
+	_, err = client.UpdateWithContext(context.Background(), testBotID, &api.StatsUpdate{Stats: &api.Stats{GuildCount: testGuildCount}})
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	// The mock responses include a deleted field which api.Bot does not
+	// model.
+	if len(drifts) != 2 {
+		t.Fatalf("Unexpected schema drifts: %d", len(drifts))
+	}
+
+	if drifts[0].Endpoint != endpointBot || fmt.Sprint(drifts[0].UnknownFields) != "[deleted]" {
+		t.Errorf("Unexpected bot schema drift: %+v", drifts[0])
+	}
+
+	if drifts[1].Endpoint != endpointBots || fmt.Sprint(drifts[1].UnknownFields) != "[bots[].deleted]" {
+		t.Errorf("Unexpected bots schema drift: %+v", drifts[1])
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+
+	if !strings.Contains(exposition, `discordbotsgg_unknown_fields_total{endpoint="bots",field="bots[].deleted"} 1`) {
+		t.Errorf("Missing unknown fields metric:\n%s", exposition)
+	}
+}
+
+func TestClient_strictDecoding_cache(t *testing.T) {
+	drifts := 0
+
+	client := NewClientWithOptions(
+		WithHTTPClient(mock.NewHTTPClient()),
+		WithCache(NewMemoryCache(0, 0), testCacheTTL, 0),
+		WithStrictDecoding(func(*SchemaDrift) {
+			drifts++
+		}),
+	)
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+		if err != nil {
+			t.Fatalf(queryBotErrorMessage, err)
+		}
+	}
+
+	// The drift of the cached response is only reported when it is received.
+	if drifts != 1 {
+		t.Errorf("Unexpected schema drifts reported for cached response: %d", drifts)
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	value := map[string]interface{}{
+		"count": 1.0,
+		"BOTS": []interface{}{
+			map[string]interface{}{
+				"username": "bot",
+				"owner":    map[string]interface{}{"userId": "1", "avatar": "a"},
+				"coOwners": []interface{}{map[string]interface{}{"flags": 1.0}},
+			},
+		},
+		"next": nil,
+	}
+
+	var fields []string
+
+	unknownFields(value, reflect.TypeOf(&api.Page{}), "", &fields)
+
+	expected := map[string]bool{
+		"next":                    true,
+		"BOTS[].owner.avatar":     true,
+		"BOTS[].coOwners[].flags": true,
+	}
+
+	if len(fields) != len(expected) {
+		t.Fatalf("Unexpected unknown fields: %v", fields)
+	}
+
+	for _, field := range fields {
+		if !expected[field] {
+			t.Errorf("Unexpected unknown field: %s", field)
+		}
+	}
+
+	fields = nil
+
+	unknownFields(map[string]interface{}{"guildCount": 1.0, "shardCount": 1.0}, reflect.TypeOf(&api.StatsResponse{}), "", &fields)
+
+	if len(fields) != 0 {
+		t.Errorf("Unexpected unknown fields of embedded struct: %v", fields)
+	}
+}
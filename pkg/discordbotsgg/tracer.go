@@ -0,0 +1,105 @@
+package discordbotsgg
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	spanQueryBot    = "discordbotsgg.QueryBot"
+	spanQueryBots   = "discordbotsgg.QueryBots"
+	spanUpdate      = "discordbotsgg.Update"
+	spanLimiterWait = "discordbotsgg.limiterWait"
+	spanRoundTrip   = "discordbotsgg.roundTrip"
+
+	attributeBotID      = "discordbotsgg.bot_id"
+	attributeEndpoint   = "discordbotsgg.endpoint"
+	attributeAttempt    = "discordbotsgg.attempt"
+	attributeMethod     = "http.method"
+	attributeURL        = "http.url"
+	attributeStatusCode = "http.status_code"
+
+	headerTraceParent = "traceparent"
+)
+
+// Tracer starts the spans of the calls of a *Client, such as an adapter to
+// OpenTelemetry. Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start starts a span with the given name as a child of the span in the
+	// given context, if any, and returns a context carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetAttribute sets an attribute of the span.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records an error which occurred during the span.
+	RecordError(err error)
+
+	// TraceParent returns the W3C Trace Context traceparent header value
+	// identifying the span, or an empty string to not propagate it.
+	TraceParent() string
+
+	// End ends the span.
+	End()
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(string, interface{}) {}
+
+func (nopSpan) RecordError(error) {}
+
+func (nopSpan) TraceParent() string {
+	return ""
+}
+
+func (nopSpan) End() {}
+
+// WithTracer sets the Tracer starting a span for every QueryBot, QueryBots
+// and Update call, with child spans for each rate limiter wait and HTTP round
+// trip. The traceparent header of each round trip span is sent with its
+// request. The default starts no spans.
+func WithTracer(tracer Tracer) Option {
+	return func(client *Client) {
+		client.Tracer = tracer
+	}
+}
+
+// startSpan starts a span with the *Client Tracer, which is unset for a
+// *Client not created by a constructor.
+func (client *Client) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if client.Tracer == nil {
+		return ctx, nopSpan{}
+	}
+
+	return client.Tracer.Start(ctx, name)
+}
+
+// endSpan ends the given Span, recording the given error, if any.
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+// injectTraceParent sets the traceparent header of the given *http.Request
+// to identify the given Span, if it is propagated.
+func injectTraceParent(req *http.Request, span Span) {
+	traceParent := span.TraceParent()
+	if traceParent == "" {
+		return
+	}
+
+	req.Header.Set(headerTraceParent, traceParent)
+}
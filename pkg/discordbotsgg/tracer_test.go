@@ -0,0 +1,180 @@
+package discordbotsgg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ewohltman/go-discordbotsgg/pkg/api"
+	"github.com/ewohltman/go-discordbotsgg/pkg/mock"
+)
+
+type testSpanKey struct{}
+
+type testSpan struct {
+	tracer     *testTracer
+	id         int
+	name       string
+	parent     *testSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (span *testSpan) SetAttribute(key string, value interface{}) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+
+	span.attributes[key] = value
+}
+
+func (span *testSpan) RecordError(err error) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+
+	span.err = err
+}
+
+func (span *testSpan) TraceParent() string {
+	return fmt.Sprintf("00-%032x-%016x-01", 1, span.id)
+}
+
+func (span *testSpan) End() {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+
+	span.ended = true
+}
+
+type testTracer struct {
+	mutex sync.Mutex
+	spans []*testSpan
+}
+
+func (tracer *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	parent, _ := ctx.Value(testSpanKey{}).(*testSpan)
+
+	span := &testSpan{
+		tracer:     tracer,
+		id:         len(tracer.spans) + 1,
+		name:       name,
+		parent:     parent,
+		attributes: make(map[string]interface{}),
+	}
+
+	tracer.spans = append(tracer.spans, span)
+
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func (tracer *testTracer) named(name string) []*testSpan {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	var spans []*testSpan
+
+	for _, span := range tracer.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+
+	return spans
+}
+
+func TestClient_Tracer(t *testing.T) {
+	var traceParents []string
+
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		traceParents = append(traceParents, req.Header.Get("traceparent"))
+		return mock.NewTransport().RoundTrip(req)
+	})
+
+	tracer := &testTracer{}
+
+	client := NewClientWithOptions(WithHTTPClient(httpClient), WithBotToken(testBotToken), WithTracer(tracer))
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if err != nil {
+		t.Fatalf(queryBotErrorMessage, err)
+	}
+
+	_, err = client.QueryBotsWithContext(context.Background(), &api.QueryParameters{})
+	if err != nil {
+		t.Fatalf(queryBotsErrorMessage, err)
+	}
+
+	_, err = client.UpdateWithContext(context.Background(), testBotID, &api.StatsUpdate{Stats: &api.Stats{}})
+	if err != nil {
+		t.Fatalf(updateBotStatsErrorMessage, err)
+	}
+
+	for _, name := range []string{spanQueryBot, spanQueryBots, spanUpdate} {
+		spans := tracer.named(name)
+		if len(spans) != 1 || spans[0].parent != nil || !spans[0].ended {
+			t.Errorf("Unexpected %s spans: %+v", name, spans)
+		}
+	}
+
+	roundTrips := tracer.named(spanRoundTrip)
+	if len(roundTrips) != 3 {
+		t.Fatalf("Unexpected round trip spans: %d", len(roundTrips))
+	}
+
+	for i, span := range roundTrips {
+		if span.parent == nil || !span.ended || span.attributes[attributeStatusCode] != http.StatusOK {
+			t.Errorf("Unexpected round trip span: %+v", span)
+		}
+
+		if traceParents[i] != span.TraceParent() {
+			t.Errorf("Unexpected traceparent. Got: %s. Expected: %s.", traceParents[i], span.TraceParent())
+		}
+	}
+
+	if limiterWaits := tracer.named(spanLimiterWait); len(limiterWaits) != 3 || limiterWaits[0].parent.name != spanQueryBot {
+		t.Errorf("Unexpected limiter wait spans: %+v", limiterWaits)
+	}
+}
+
+func TestClient_Tracer_error(t *testing.T) {
+	httpClient := newTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return newTestResponse(req, http.StatusNotFound, nil, ""), nil
+	})
+
+	tracer := &testTracer{}
+
+	client := NewClientWithOptions(WithHTTPClient(httpClient), WithTracer(tracer))
+	defer client.Close()
+
+	_, err := client.QueryBotWithContext(context.Background(), testBotID, false)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, name := range []string{spanQueryBot, spanRoundTrip} {
+		spans := tracer.named(name)
+		if len(spans) != 1 || !errors.Is(spans[0].err, ErrNotFound) {
+			t.Errorf("Unexpected %s spans: %+v", name, spans)
+		}
+	}
+}
+
+func TestClient_startSpan(t *testing.T) {
+	client := &Client{}
+
+	ctx := context.Background()
+
+	spanCtx, span := client.startSpan(ctx, spanQueryBot)
+	if spanCtx != ctx || span.TraceParent() != "" {
+		t.Errorf("Unexpected span without Tracer")
+	}
+
+	endSpan(span, errors.New("error"))
+}
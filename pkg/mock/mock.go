@@ -105,6 +105,11 @@ func botsResponse(req *http.Request, resp *http.Response) error {
 		return err
 	}
 
+	if req.URL.Query().Get("unverified") == "true" && req.Header.Get("Authorization") == "" {
+		unauthorizedResponse(resp)
+		return nil
+	}
+
 	respBody := []byte(botsResponseString)
 
 	resp.ContentLength = int64(len(respBody))
@@ -161,6 +166,15 @@ func readRequestBody(req *http.Request) (reqBody []byte, err error) {
 	return ioutil.ReadAll(req.Body)
 }
 
+func unauthorizedResponse(resp *http.Response) {
+	respBody := []byte(unauthorizedResponseString)
+
+	resp.StatusCode = http.StatusUnauthorized
+	resp.Status = http.StatusText(http.StatusUnauthorized)
+	resp.ContentLength = int64(len(respBody))
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+}
+
 func badRequestResponse(resp *http.Response) {
 	resp.StatusCode = http.StatusBadRequest
 	resp.Status = http.StatusText(http.StatusBadRequest)
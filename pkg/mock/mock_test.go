@@ -66,6 +66,11 @@ func doTestRequests(client *http.Client) error {
 		return err
 	}
 
+	err = doTestRequest(client, http.MethodGet, api.BotsEndpoint(&api.QueryParameters{Unverified: true}), nil)
+	if err != nil {
+		return err
+	}
+
 	statsUpdate := &api.StatsUpdate{
 		Stats: &api.Stats{
 			GuildCount: testGuildCount,
@@ -102,3 +102,9 @@ const botsResponseString = `
   ]
 }
 `
+
+const unauthorizedResponseString = `
+{
+  "message": "Unauthorized"
+}
+`